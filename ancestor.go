@@ -0,0 +1,48 @@
+package euler
+
+// trees are rooted at the first vertex of euler tour, Reroot changes root,
+// depths of vertices are not saved because rerooting changes them on the whole path,
+// so ancestry is found by positions of vertex entries in tour,
+// LinkCutTree.LCA answers lowest common ancestor for forest kept by caller
+
+// IsAncestor return true if u is on path from root of tree to v,
+// vertex is ancestor of itself
+//
+// O(D*log(N)) complexity for D edges of u
+func (tree *Euler[V]) IsAncestor(u, v V) bool {
+	if !tree.IsConnected(u, v) {
+		return false
+	}
+
+	// tour of subtree of u is between its first and last entries
+	first, last := tree.occurrences(u)
+	index := tree.queryTreap(v).index()
+	return first.index() <= index && index <= last.index()
+}
+
+// occurrences returns the first and the last entries of v in tour
+func (tree *Euler[V]) occurrences(v V) (first, last *Treap[V]) {
+	root := tree.queryTreap(v).Root()
+	first, last = root.leftmost(), root.rightmost()
+	if first.vertex == v {
+		return first, last
+	}
+
+	// every entry of not first vertex is after arc from its neighbour
+	first, last = nil, nil
+	firstIndex, lastIndex := 0, 0
+	for _, edge := range tree.edges[v] {
+		entry := edge.First
+		if entry.vertex != v {
+			entry = edge.Second
+		}
+		index := entry.index()
+		if first == nil || index < firstIndex {
+			first, firstIndex = entry, index
+		}
+		if last == nil || index > lastIndex {
+			last, lastIndex = entry, index
+		}
+	}
+	return first, last
+}
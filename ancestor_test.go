@@ -0,0 +1,154 @@
+package euler
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestEuler_IsAncestor(t *testing.T) {
+	// 1 - 2 - 3
+	//      \
+	//       4 - 5
+	tree := createTestTreeByLink(
+		[]struct{ a, b int }{
+			{1, 2},
+			{2, 3},
+			{2, 4},
+			{4, 5},
+		},
+		[]int{6},
+	)
+	tree.Reroot(1)
+
+	tests := []struct {
+		u, v     Vertex
+		expected bool
+	}{
+		{1, 5, true},
+		{2, 3, true},
+		{4, 5, true},
+		{5, 5, true},
+		{3, 5, false},
+		{5, 2, false},
+		{1, 6, false},
+	}
+	for _, test := range tests {
+		if got := tree.IsAncestor(test.u, test.v); got != test.expected {
+			alarm(t, "IsAncestor", tree.Strings(), test.u, test.v, test.expected, got)
+		}
+	}
+
+	tree.Reroot(5)
+	if !tree.IsAncestor(4, 1) || tree.IsAncestor(1, 4) {
+		t.Errorf("%v: IsAncestor ignores Reroot", tree.Strings())
+	}
+}
+
+func TestLinkCutTree_LCA(t *testing.T) {
+	const numbers = 50
+	random := rand.New(rand.NewSource(1))
+	tree := CreateLinkCutTree()
+	naive := make(naiveForest)
+
+	for i := 0; i < 3000; i++ {
+		a, b := random.Intn(numbers), random.Intn(numbers)
+		switch random.Intn(4) {
+		case 0:
+			if tree.Link(a, b) {
+				naive.setEdge(a, b, 0)
+			}
+		case 1:
+			if tree.Cut(a, b) {
+				delete(naive[a], b)
+				delete(naive[b], a)
+			}
+		default:
+			root := random.Intn(numbers)
+			got, ok := tree.LCA(root, a, b)
+
+			aPath, aOk := naive.vertexPath(a, root)
+			bPath, bOk := naive.vertexPath(b, root)
+			if ok != (aOk && bOk) {
+				t.Fatalf("LCA(%v, %v, %v)\nExpected connected %v\nGot %v", root, a, b, aOk && bOk, ok)
+			}
+			if !ok {
+				continue
+			}
+
+			// paths to root have common end
+			expected := root
+			for j := 1; j <= min(len(aPath), len(bPath)); j++ {
+				if aPath[len(aPath)-j] != bPath[len(bPath)-j] {
+					break
+				}
+				expected = aPath[len(aPath)-j]
+			}
+			if got != expected {
+				t.Fatalf("LCA(%v, %v, %v)\nExpected %v\nGot %v", root, a, b, expected, got)
+			}
+		}
+	}
+}
+
+// vertexPath returns vertices of path from first to second
+func (f naiveForest) vertexPath(first, second Vertex) ([]Vertex, bool) {
+	parent := map[Vertex]Vertex{second: second}
+	stack := []Vertex{second}
+	for len(stack) > 0 {
+		v := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		for u := range f[v] {
+			if _, visited := parent[u]; !visited {
+				parent[u] = v
+				stack = append(stack, u)
+			}
+		}
+	}
+	if _, ok := parent[first]; !ok {
+		return nil, false
+	}
+
+	path := []Vertex{first}
+	for v := first; v != second; v = parent[v] {
+		path = append(path, parent[v])
+	}
+	return path, true
+}
+
+func TestEuler_AncestryRandom(t *testing.T) {
+	const numbers = 30
+	random := rand.New(rand.NewSource(2))
+	tree := CreateEuler()
+	naive := make(naiveForest)
+
+	for i := 0; i < 3000; i++ {
+		a, b := random.Intn(numbers), random.Intn(numbers)
+		switch random.Intn(4) {
+		case 0:
+			if tree.Link(a, b) {
+				naive.setEdge(a, b, 0)
+			}
+		case 1:
+			if tree.Cut(a, b) {
+				delete(naive[a], b)
+				delete(naive[b], a)
+			}
+		case 2:
+			tree.Reroot(a)
+		default:
+			if !tree.HasVertex(a) || !tree.HasVertex(b) {
+				continue
+			}
+			// root of tree is the first vertex of tour
+			cursor := tree.Cursor(a)
+			root := cursor.Vertex()
+			path, ok := naive.vertexPath(b, root)
+			expected := ok && slices.Contains(path, a)
+			if got := tree.IsAncestor(a, b); got != expected {
+				alarm(t, "IsAncestor", tree.Strings(), a, b, expected, got)
+			}
+		}
+		testValidate(t, tree)
+	}
+}
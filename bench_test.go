@@ -93,3 +93,28 @@ func BenchmarkEulerRandomOnlyRead100000(b *testing.B) {
 func BenchmarkEulerRandomOnlyRead1000000(b *testing.B) {
 	benchmarkEulerRandom(b, 0, 1000000)
 }
+
+//
+// full tour traversal
+//
+
+func benchmarkEulerTour(b *testing.B, numbers int) {
+	tree := CreateEuler()
+	for j := 1; j < numbers; j++ {
+		tree.Link(rand.Intn(j), j)
+	}
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		for range tree.Tour(0) {
+		}
+	}
+}
+
+func BenchmarkEulerTour1000(b *testing.B) {
+	benchmarkEulerTour(b, 1000)
+}
+
+func BenchmarkEulerTour1000000(b *testing.B) {
+	benchmarkEulerTour(b, 1000000)
+}
@@ -0,0 +1,393 @@
+package euler
+
+import (
+	"bufio"
+	"bytes"
+	"encoding"
+	"encoding/binary"
+	"errors"
+	"io"
+	"math"
+	"reflect"
+)
+
+// version of binary format
+const binaryVersion = 1
+
+var (
+	// ErrUnsupportedVertex vertex is not integer, string or encoding.BinaryMarshaler
+	ErrUnsupportedVertex = errors.New("euler: unsupported vertex type")
+	// ErrInvalidData binary data is not encoded euler forest
+	ErrInvalidData = errors.New("euler: invalid binary data")
+)
+
+// MarshalBinary encodes vertices, edges and tours of forest,
+// values of vertices and data of edges are not encoded
+//
+// vertices should be integers, strings or implement encoding.BinaryMarshaler
+func (tree *Euler[V]) MarshalBinary() ([]byte, error) {
+	var buffer bytes.Buffer
+	if _, err := tree.WriteTo(&buffer); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+// UnmarshalBinary replaces forest by decoded one
+//
+// O(N) complexity
+func (tree *Euler[V]) UnmarshalBinary(data []byte) error {
+	_, err := tree.ReadFrom(bytes.NewReader(data))
+	return err
+}
+
+// WriteTo writes forest in format of MarshalBinary
+//
+// format:
+//  version
+//  number of trees
+//  for each tree:
+//   number of vertices
+//   first vertex of tour
+//   bits of tour moves: 1 - down to new vertex, 0 - up to parent
+//   vertices of down moves
+func (tree *Euler[V]) WriteTo(w io.Writer) (int64, error) {
+	writer := &binaryWriter{w: bufio.NewWriter(w)}
+
+	writer.writeUvarint(binaryVersion)
+	roots := tree.roots()
+	writer.writeUvarint(uint64(len(roots)))
+	for _, root := range roots {
+		writeTour(writer, root)
+	}
+
+	if writer.err == nil {
+		writer.err = writer.w.Flush()
+	}
+	return writer.n, writer.err
+}
+
+// ReadFrom replaces forest by forest written by WriteTo,
+// r without io.ByteReader is buffered by bufio.Reader, so it may be read past the forest,
+// pass io.ByteReader (like bufio.Reader) to read exactly the forest from a stream,
+// returned count is number of bytes of forest
+//
+// O(N) complexity
+func (tree *Euler[V]) ReadFrom(r io.Reader) (int64, error) {
+	source, ok := r.(byteReader)
+	if !ok {
+		source = bufio.NewReader(r)
+	}
+	reader := &binaryReader{r: source}
+
+	if reader.readUvarint() != binaryVersion && reader.err == nil {
+		reader.err = ErrInvalidData
+	}
+	trees := reader.readUvarint()
+
+	// forest is replaced only if there are no errors
+	result := CreateEulerFunc(tree.compare)
+	result.aggregation, result.priority = tree.aggregation, tree.priority
+	for i := uint64(0); i < trees && reader.err == nil; i++ {
+		result.readTour(reader)
+	}
+
+	if errors.Is(reader.err, io.EOF) || errors.Is(reader.err, io.ErrUnexpectedEOF) {
+		reader.err = ErrInvalidData
+	}
+	if reader.err == nil {
+		tree.treaps, tree.edges = result.treaps, result.edges
+	}
+	return reader.n, reader.err
+}
+
+func writeTour[V comparable](writer *binaryWriter, root *Treap[V]) {
+	vertices := (root.size + 1) / 2
+	writer.writeUvarint(uint64(vertices))
+	first := root.leftmost()
+	writeVertex(writer, first.vertex)
+
+	// path from first vertex to current entry
+	stack := []V{first.vertex}
+	moves := make([]byte, (2*(vertices-1)+7)/8)
+	i := 0
+	for entry := first.next(); entry != nil; entry = entry.next() {
+		if len(stack) > 1 && stack[len(stack)-2] == entry.vertex {
+			stack = stack[:len(stack)-1]
+		} else {
+			stack = append(stack, entry.vertex)
+			moves[i/8] |= 1 << (i % 8)
+		}
+		i++
+	}
+	writer.write(moves)
+
+	i = 0
+	for entry := first.next(); entry != nil; entry = entry.next() {
+		if moves[i/8]&(1<<(i%8)) != 0 {
+			writeVertex(writer, entry.vertex)
+		}
+		i++
+	}
+}
+
+// readTour restores tour, edges and own entries from moves,
+// treap is built from sorted entries in linear time
+func (tree *Euler[V]) readTour(reader *binaryReader) {
+	vertices := reader.readUvarint()
+	if vertices == 0 || vertices > math.MaxInt32 {
+		reader.fail(ErrInvalidData)
+	}
+	first := readVertex[V](reader)
+	if _, ok := tree.treaps[first]; ok {
+		reader.fail(ErrInvalidData)
+	}
+	if reader.err != nil {
+		return
+	}
+	var moves []byte
+	if vertices > 1 {
+		moves = reader.readBytes((2*(vertices-1) + 7) / 8)
+	}
+
+	entries := []*Treap[V]{tree.createOwnTreap(first)}
+	// path from first vertex and edges of path
+	stack := []V{first}
+	var edges []*Edge[V]
+	for i := uint64(0); i < 2*(vertices-1) && reader.err == nil; i++ {
+		var entry *Treap[V]
+		if moves[i/8]&(1<<(i%8)) != 0 {
+			v := readVertex[V](reader)
+			if _, ok := tree.treaps[v]; ok || reader.err != nil {
+				reader.fail(ErrInvalidData)
+				return
+			}
+			entry = tree.createOwnTreap(v)
+			edge := &Edge[V]{First: entry}
+			entry.edge = edge
+			tree.setEdge(stack[len(stack)-1], v, edge)
+			stack = append(stack, v)
+			edges = append(edges, edge)
+		} else {
+			if len(stack) < 2 {
+				reader.fail(ErrInvalidData)
+				return
+			}
+			stack = stack[:len(stack)-1]
+			entry = tree.createTreap(stack[len(stack)-1])
+			edge := edges[len(edges)-1]
+			edges = edges[:len(edges)-1]
+			edge.Second = entry
+			entry.edge = edge
+		}
+		entries = append(entries, entry)
+	}
+	if len(stack) != 1 {
+		reader.fail(ErrInvalidData)
+		return
+	}
+
+	buildTreap(entries, tree.aggregation)
+}
+
+// createOwnTreap creates entry linked from Euler, vertex should be new
+func (tree *Euler[V]) createOwnTreap(v V) *Treap[V] {
+	result := tree.createTreap(v)
+	result.own = true
+	if tree.aggregation != nil && tree.aggregation.monoid != nil {
+		result.values.value = tree.aggregation.monoid.Identity
+	}
+	tree.treaps[v] = result
+	return result
+}
+
+// buildTreap links entries into treap keeping their order, returns root
+//
+// O(N) complexity
+func buildTreap[V comparable](entries []*Treap[V], a *aggregation) *Treap[V] {
+	// right spine of treap
+	var stack []*Treap[V]
+	for _, entry := range entries {
+		var last *Treap[V]
+		for len(stack) > 0 && stack[len(stack)-1].priority < entry.priority {
+			last = stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+		}
+		entry.left = last
+		last.setParent(entry)
+		if len(stack) > 0 {
+			parent := stack[len(stack)-1]
+			parent.right = entry
+			entry.parent = parent
+		}
+		stack = append(stack, entry)
+	}
+	if len(stack) == 0 {
+		return nil
+	}
+
+	root := stack[0]
+	root.updateSubtree(a)
+	return root
+}
+
+// updateSubtree updates all entries of t from leaves to root
+func (t *Treap[V]) updateSubtree(a *aggregation) {
+	if t != nil {
+		t.left.updateSubtree(a)
+		t.right.updateSubtree(a)
+		t.updateSize(a)
+	}
+}
+
+func writeVertex[V comparable](writer *binaryWriter, v V) {
+	value := reflect.ValueOf(&v).Elem()
+	switch value.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		writer.writeVarint(value.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		writer.writeUvarint(value.Uint())
+	case reflect.String:
+		writer.writeUvarint(uint64(value.Len()))
+		writer.write([]byte(value.String()))
+	default:
+		marshaler, ok := any(v).(encoding.BinaryMarshaler)
+		if !ok {
+			writer.fail(ErrUnsupportedVertex)
+			return
+		}
+		data, err := marshaler.MarshalBinary()
+		if err != nil {
+			writer.fail(err)
+			return
+		}
+		writer.writeUvarint(uint64(len(data)))
+		writer.write(data)
+	}
+}
+
+func readVertex[V comparable](reader *binaryReader) V {
+	var v V
+	value := reflect.ValueOf(&v).Elem()
+	switch value.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		x := reader.readVarint()
+		if value.OverflowInt(x) {
+			reader.fail(ErrInvalidData)
+		}
+		value.SetInt(x)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		x := reader.readUvarint()
+		if value.OverflowUint(x) {
+			reader.fail(ErrInvalidData)
+		}
+		value.SetUint(x)
+	case reflect.String:
+		value.SetString(string(reader.readBytes(reader.readUvarint())))
+	default:
+		unmarshaler, ok := any(&v).(encoding.BinaryUnmarshaler)
+		if !ok {
+			reader.fail(ErrUnsupportedVertex)
+			return v
+		}
+		data := reader.readBytes(reader.readUvarint())
+		if reader.err == nil {
+			reader.fail(unmarshaler.UnmarshalBinary(data))
+		}
+	}
+	return v
+}
+
+// binaryWriter counts written bytes and keeps first error
+type binaryWriter struct {
+	w   *bufio.Writer
+	n   int64
+	err error
+}
+
+func (w *binaryWriter) fail(err error) {
+	if w.err == nil {
+		w.err = err
+	}
+}
+
+func (w *binaryWriter) write(data []byte) {
+	if w.err != nil {
+		return
+	}
+	n, err := w.w.Write(data)
+	w.n += int64(n)
+	w.fail(err)
+}
+
+func (w *binaryWriter) writeUvarint(x uint64) {
+	var buffer [binary.MaxVarintLen64]byte
+	w.write(buffer[:binary.PutUvarint(buffer[:], x)])
+}
+
+func (w *binaryWriter) writeVarint(x int64) {
+	var buffer [binary.MaxVarintLen64]byte
+	w.write(buffer[:binary.PutVarint(buffer[:], x)])
+}
+
+type byteReader interface {
+	io.Reader
+	io.ByteReader
+}
+
+// binaryReader counts read bytes and keeps first error
+type binaryReader struct {
+	r   byteReader
+	n   int64
+	err error
+}
+
+func (r *binaryReader) fail(err error) {
+	if r.err == nil {
+		r.err = err
+	}
+}
+
+func (r *binaryReader) ReadByte() (byte, error) {
+	b, err := r.r.ReadByte()
+	if err == nil {
+		r.n++
+	}
+	return b, err
+}
+
+// readBytes reads n bytes, buffer grows with read data, so wrong n doesn't allocate much
+func (r *binaryReader) readBytes(n uint64) []byte {
+	if n > math.MaxInt32 {
+		r.fail(ErrInvalidData)
+	}
+	if r.err != nil {
+		return nil
+	}
+	var buffer bytes.Buffer
+	read, err := io.CopyN(&buffer, r.r, int64(n))
+	r.n += read
+	if read != int64(n) && err == nil {
+		err = io.ErrUnexpectedEOF
+	}
+	r.fail(err)
+	return buffer.Bytes()
+}
+
+func (r *binaryReader) readUvarint() uint64 {
+	if r.err != nil {
+		return 0
+	}
+	x, err := binary.ReadUvarint(r)
+	r.fail(err)
+	return x
+}
+
+func (r *binaryReader) readVarint() int64 {
+	if r.err != nil {
+		return 0
+	}
+	x, err := binary.ReadVarint(r)
+	r.fail(err)
+	return x
+}
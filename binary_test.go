@@ -0,0 +1,159 @@
+package euler
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"io"
+	"math/rand"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func sortedStrings[V comparable](tree *Euler[V]) []string {
+	result := tree.Strings()
+	slices.Sort(result)
+	return result
+}
+
+func TestEuler_MarshalBinary(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	tree := CreateEuler()
+	edges := make(map[[2]Vertex]bool)
+	for i := 0; i < 1000; i++ {
+		a, b := random.Intn(300)-100, random.Intn(300)-100
+		if tree.Link(a, b) {
+			edges[[2]Vertex{a, b}] = true
+		}
+	}
+	tree.getTreap(1000)
+
+	data, err := tree.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary returned error %v", err)
+	}
+	restored := CreateEuler()
+	if err := restored.UnmarshalBinary(data); err != nil {
+		t.Fatalf("UnmarshalBinary returned error %v", err)
+	}
+	testValidate(t, restored)
+
+	if expected, got := sortedStrings(tree), sortedStrings(restored); !reflect.DeepEqual(expected, got) {
+		t.Fatalf("UnmarshalBinary\nExpected %v\nGot %v", expected, got)
+	}
+	for v := range tree.treaps {
+		if restored.ComponentSize(v) != tree.ComponentSize(v) || !restored.treaps[v].own {
+			t.Errorf("UnmarshalBinary: wrong vertex %v", v)
+		}
+	}
+
+	// edges are restored
+	for edge := range edges {
+		if !tree.Cut(edge[0], edge[1]) || !restored.Cut(edge[0], edge[1]) {
+			t.Fatalf("Cut(%v, %v) returned false", edge[0], edge[1])
+		}
+		if expected, got := sortedStrings(tree), sortedStrings(restored); !reflect.DeepEqual(expected, got) {
+			t.Fatalf("Cut(%v, %v) after UnmarshalBinary\nExpected %v\nGot %v", edge[0], edge[1], expected, got)
+		}
+	}
+}
+
+func TestEuler_WriteTo(t *testing.T) {
+	tree := CreateEulerFunc[string](nil)
+	tree.Link("a", "b")
+	tree.Link("b", "c")
+	tree.Link("d", "e")
+
+	var buffer bytes.Buffer
+	written, err := tree.WriteTo(&buffer)
+	if err != nil || written != int64(buffer.Len()) {
+		t.Fatalf("WriteTo returned %v, %v, buffer has %v bytes", written, err, buffer.Len())
+	}
+
+	restored := CreateEulerFunc[string](nil)
+	read, err := restored.ReadFrom(&buffer)
+	if err != nil || read != written {
+		t.Fatalf("ReadFrom returned %v, %v, expected %v bytes", read, err, written)
+	}
+	if expected, got := sortedStrings(tree), sortedStrings(restored); !reflect.DeepEqual(expected, got) {
+		t.Errorf("ReadFrom\nExpected %v\nGot %v", expected, got)
+	}
+}
+
+func TestEuler_ReadFromStream(t *testing.T) {
+	first := CreateEuler()
+	first.Link(1, 2)
+	second := CreateEuler()
+	second.Link(3, 4)
+	second.Link(4, 5)
+
+	var buffer bytes.Buffer
+	firstWritten, _ := first.WriteTo(&buffer)
+	secondWritten, _ := second.WriteTo(&buffer)
+	buffer.WriteString("tail")
+
+	// io.ByteReader is read exactly, other readers are buffered
+	for name, r := range map[string]io.Reader{
+		"byte reader":     bytes.NewReader(buffer.Bytes()),
+		"buffered reader": bufio.NewReader(struct{ io.Reader }{bytes.NewReader(buffer.Bytes())}),
+	} {
+		for _, test := range []struct {
+			expected *Euler[int]
+			written  int64
+		}{{first, firstWritten}, {second, secondWritten}} {
+			restored := CreateEuler()
+			read, err := restored.ReadFrom(r)
+			if err != nil || read != test.written {
+				t.Fatalf("%v: ReadFrom returned %v, %v, expected %v bytes", name, read, err, test.written)
+			}
+			if expected, got := sortedStrings(test.expected), sortedStrings(restored); !reflect.DeepEqual(expected, got) {
+				t.Errorf("%v: ReadFrom\nExpected %v\nGot %v", name, expected, got)
+			}
+		}
+		if tail, _ := io.ReadAll(r); string(tail) != "tail" {
+			t.Errorf("%v: data after forests\nExpected tail\nGot %v", name, string(tail))
+		}
+	}
+	restored := CreateEuler()
+	read, err := restored.ReadFrom(struct{ io.Reader }{bytes.NewReader(buffer.Bytes())})
+	if err != nil || read != firstWritten || !reflect.DeepEqual(sortedStrings(first), sortedStrings(restored)) {
+		t.Errorf("reader: ReadFrom returned %v, %v, expected %v bytes of %v", read, err, firstWritten, first.Strings())
+	}
+}
+
+func TestEuler_UnmarshalBinaryErrors(t *testing.T) {
+	tree := CreateEuler()
+	tree.Link(1, 2)
+	tree.Link(2, 3)
+	data, _ := tree.MarshalBinary()
+
+	tests := [][]byte{
+		nil,
+		{2, 0},
+		data[:len(data)-1],
+		// up move from first vertex
+		{1, 1, 2, 2, 0},
+		// first vertex is repeated
+		{1, 2, 1, 2, 1, 2},
+	}
+	for _, test := range tests {
+		restored := CreateEuler()
+		restored.Link(5, 6)
+		err := restored.UnmarshalBinary(test)
+
+		if !errors.Is(err, ErrInvalidData) {
+			t.Errorf("UnmarshalBinary(%v)\nExpected %v\nGot %v", test, ErrInvalidData, err)
+		}
+		if got := restored.String(); got != "5-6-5" {
+			t.Errorf("UnmarshalBinary(%v) changed forest to %v", test, got)
+		}
+	}
+
+	type point struct{ x, y int }
+	points := CreateEulerFunc[point](nil)
+	points.Link(point{1, 2}, point{3, 4})
+	if _, err := points.MarshalBinary(); !errors.Is(err, ErrUnsupportedVertex) {
+		t.Errorf("MarshalBinary\nExpected %v\nGot %v", ErrUnsupportedVertex, err)
+	}
+}
@@ -0,0 +1,142 @@
+package euler
+
+import "slices"
+
+// 2-edge-connectivity is found on demand in spanning forest of the first level of DynamicGraph,
+// tree edge is a bridge if no non-tree edge connects subtree under it with the rest of component,
+// search result is cached for component until AddEdge or RemoveEdge of its vertices
+
+// Bridges returns edges of component of v, removal of any of them disconnects the component,
+// bridge is ordered from parent to child in euler tour of spanning forest
+//
+// O(N+M) complexity for N vertices and M edges of component after update, O(B) for B bridges otherwise
+func (g *DynamicGraph[V]) Bridges(v V) [][2]V {
+	if g.edges[v] == nil {
+		return nil
+	}
+	return slices.Clone(g.getBridges(v).bridges)
+}
+
+// TwoEdgeConnected return true if vertices stay connected after removal of any edge,
+// vertex is 2-edge-connected with itself
+//
+// O(N+M) complexity for N vertices and M edges of component after update, O(1) otherwise
+func (g *DynamicGraph[V]) TwoEdgeConnected(first, second V) bool {
+	if first == second {
+		return true
+	}
+	search, ok := g.bridges[first]
+	if !ok {
+		if !g.IsConnected(first, second) {
+			return false
+		}
+		search = g.getBridges(first)
+	}
+	// vertices of other components aren't in search
+	i, ok := search.number[second]
+	return ok && search.component[search.number[first]] == search.component[i]
+}
+
+// bridgeSearch vertices of component numbered in preorder of spanning tree
+type bridgeSearch[V comparable] struct {
+	order  []V
+	number map[V]int
+	// component[i] is id of 2-edge-connected component of vertex with number i
+	component []int
+	bridges   [][2]V
+}
+
+// getBridges returns cached search of component of v or searches it
+func (g *DynamicGraph[V]) getBridges(v V) *bridgeSearch[V] {
+	if search, ok := g.bridges[v]; ok {
+		return search
+	}
+	search := g.searchBridges(v)
+	if g.bridges == nil {
+		g.bridges = make(map[V]*bridgeSearch[V])
+	}
+	for _, u := range search.order {
+		g.bridges[u] = search
+	}
+	return search
+}
+
+// dropBridges removes cached search of component of v
+func (g *DynamicGraph[V]) dropBridges(v V) {
+	search, ok := g.bridges[v]
+	if !ok {
+		return
+	}
+	for _, u := range search.order {
+		delete(g.bridges, u)
+	}
+}
+
+// searchBridges finds bridges of component of v
+func (g *DynamicGraph[V]) searchBridges(v V) *bridgeSearch[V] {
+	search := &bridgeSearch[V]{number: make(map[V]int)}
+	var parent []int
+
+	// tour goes to a new child or returns to parent of current vertex
+	var stack []int
+	for u := range g.levels[0].forest.Tour(v) {
+		if len(stack) > 1 && search.order[stack[len(stack)-2]] == u {
+			stack = stack[:len(stack)-1]
+			continue
+		}
+		if len(stack) > 0 {
+			parent = append(parent, stack[len(stack)-1])
+		} else {
+			parent = append(parent, -1)
+		}
+		search.number[u] = len(search.order)
+		stack = append(stack, len(search.order))
+		search.order = append(search.order, u)
+	}
+
+	// the lowest and the highest numbers reachable from subtree by one non-tree edge,
+	// children are after parent in preorder
+	n := len(search.order)
+	size := make([]int, n)
+	low, high := make([]int, n), make([]int, n)
+	isBridge := make([]bool, n)
+	for i := range n {
+		low[i], high[i] = i, i
+	}
+	for i := n - 1; i >= 0; i-- {
+		size[i]++
+		for u, edge := range g.edges[search.order[i]] {
+			if !edge.isTree {
+				low[i] = min(low[i], search.number[u])
+				high[i] = max(high[i], search.number[u])
+			}
+		}
+
+		p := parent[i]
+		if p < 0 {
+			continue
+		}
+		if low[i] >= i && high[i] < i+size[i] {
+			isBridge[i] = true
+			search.bridges = append(search.bridges, [2]V{search.order[p], search.order[i]})
+		}
+		size[p] += size[i]
+		low[p] = min(low[p], low[i])
+		high[p] = max(high[p], high[i])
+	}
+
+	slices.Reverse(search.bridges)
+
+	// child is in component of parent unless edge between them is a bridge
+	search.component = make([]int, n)
+	components := 1
+	for i := 1; i < n; i++ {
+		if isBridge[i] {
+			search.component[i] = components
+			components++
+		} else {
+			search.component[i] = search.component[parent[i]]
+		}
+	}
+	return search
+}
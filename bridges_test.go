@@ -0,0 +1,130 @@
+package euler
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestDynamicGraph_Bridges(t *testing.T) {
+	// 1 - 2 - 4 - 5
+	//  \ /     \ /
+	//   3       6
+	g := CreateDynamicGraph()
+	for _, edge := range [][2]Vertex{{1, 2}, {2, 3}, {3, 1}, {2, 4}, {4, 5}, {5, 6}, {6, 4}} {
+		g.AddEdge(edge[0], edge[1])
+	}
+
+	if got := g.Bridges(5); !reflect.DeepEqual(got, [][2]Vertex{{2, 4}}) {
+		t.Errorf("Bridges(5)\nExpected [[2 4]]\nGot %v", got)
+	}
+	if got := g.Bridges(7); got != nil {
+		t.Errorf("Bridges(7)\nExpected []\nGot %v", got)
+	}
+
+	tests := []struct {
+		first, second Vertex
+		expected      bool
+	}{
+		{1, 3, true},
+		{4, 6, true},
+		{1, 5, false},
+		{2, 4, false},
+		{7, 7, true},
+		{1, 7, false},
+	}
+	for _, test := range tests {
+		if got := g.TwoEdgeConnected(test.first, test.second); got != test.expected {
+			t.Errorf("TwoEdgeConnected(%v, %v)\nExpected %v\nGot %v", test.first, test.second, test.expected, got)
+		}
+	}
+
+	// cycle through both parts
+	g.AddEdge(3, 5)
+	if got := g.Bridges(1); got != nil {
+		t.Errorf("Bridges(1)\nExpected []\nGot %v", got)
+	}
+	if !g.TwoEdgeConnected(1, 6) {
+		t.Error("TwoEdgeConnected(1, 6)\nExpected true\nGot false")
+	}
+
+	// removal drops cached bridges
+	g.RemoveEdge(5, 3)
+	if got := g.Bridges(6); len(got) != 1 || g.TwoEdgeConnected(1, 6) {
+		t.Errorf("Bridges(6) after RemoveEdge\nExpected one bridge\nGot %v", got)
+	}
+
+	// update of another component keeps cached search
+	cached := g.bridges[1]
+	g.AddEdge(7, 8)
+	if g.bridges[1] != cached || g.bridges[6] != cached {
+		t.Error("AddEdge(7, 8) dropped cached bridges of component of 1")
+	}
+	if g.TwoEdgeConnected(1, 8) || g.TwoEdgeConnected(7, 8) {
+		t.Error("TwoEdgeConnected across bridge\nExpected false\nGot true")
+	}
+	g.RemoveEdge(1, 2)
+	if _, ok := g.bridges[6]; ok {
+		t.Error("RemoveEdge(1, 2) kept cached bridges of its component")
+	}
+}
+
+func TestDynamicGraph_BridgesRandom(t *testing.T) {
+	const numbers = 20
+	random := rand.New(rand.NewSource(1))
+	g := CreateDynamicGraph()
+	naive := make(naiveGraph)
+
+	for i := 0; i < 1000; i++ {
+		a, b := random.Intn(numbers), random.Intn(numbers)
+		if random.Intn(3) == 0 {
+			if g.RemoveEdge(a, b) {
+				naive.setEdge(a, b, false)
+			}
+		} else if g.AddEdge(a, b) {
+			naive.setEdge(a, b, true)
+		}
+
+		a, b = random.Intn(numbers), random.Intn(numbers)
+		expected := naive.bridges(a)
+		got := make(map[[2]Vertex]bool)
+		for _, bridge := range g.Bridges(a) {
+			got[[2]Vertex{min(bridge[0], bridge[1]), max(bridge[0], bridge[1])}] = true
+		}
+		if !reflect.DeepEqual(got, expected) {
+			t.Fatalf("Bridges(%v)\nExpected %v\nGot %v", a, expected, got)
+		}
+
+		connected := naive.isConnected(a, b)
+		for bridge := range expected {
+			naive.setEdge(bridge[0], bridge[1], false)
+			connected = connected && naive.isConnected(a, b)
+			naive.setEdge(bridge[0], bridge[1], true)
+		}
+		if got := g.TwoEdgeConnected(a, b); got != connected {
+			t.Fatalf("TwoEdgeConnected(%v, %v)\nExpected %v\nGot %v", a, b, connected, got)
+		}
+	}
+}
+
+// bridges returns edges of component of v which removal disconnects their vertices
+func (g naiveGraph) bridges(v Vertex) map[[2]Vertex]bool {
+	var edges [][2]Vertex
+	for first := range g {
+		for second := range g[first] {
+			if first < second && g.isConnected(v, first) {
+				edges = append(edges, [2]Vertex{first, second})
+			}
+		}
+	}
+
+	result := make(map[[2]Vertex]bool)
+	for _, edge := range edges {
+		g.setEdge(edge[0], edge[1], false)
+		if !g.isConnected(edge[0], edge[1]) {
+			result[edge] = true
+		}
+		g.setEdge(edge[0], edge[1], true)
+	}
+	return result
+}
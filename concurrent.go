@@ -0,0 +1,159 @@
+package euler
+
+import (
+	"cmp"
+	"slices"
+	"sync"
+)
+
+// ConcurrentEuler is Euler that is safe for concurrent use,
+// forest is strict, so queries don't create unknown vertices and run in parallel under read lock,
+// changes are guarded by write lock
+type ConcurrentEuler[V comparable] struct {
+	mutex sync.RWMutex
+	tree  *Euler[V]
+}
+
+// CreateConcurrentEuler making empty tree with int vertices
+func CreateConcurrentEuler() *ConcurrentEuler[int] {
+	return CreateConcurrentEulerFunc(cmp.Compare[int])
+}
+
+// CreateConcurrentEulerFunc making empty tree with any comparable vertices,
+// compare and options are used like in CreateEulerFunc
+func CreateConcurrentEulerFunc[V comparable](compare func(a, b V) int, opts ...Option) *ConcurrentEuler[V] {
+	opts = append(slices.Clip(opts), WithStrict())
+	return &ConcurrentEuler[V]{tree: CreateEulerFunc(compare, opts...)}
+}
+
+// IsConnected return true if vertices are in one tree,
+// unknown vertex is connected only with itself
+func (c *ConcurrentEuler[V]) IsConnected(first, second V) bool {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	return c.tree.IsConnected(first, second)
+}
+
+// Link creates edge in forest
+//
+// returns false if vertices are already linked
+func (c *ConcurrentEuler[V]) Link(first, second V) bool {
+	return c.LinkWithData(first, second, nil)
+}
+
+// LinkWithData creates edge with data in forest
+//
+// returns false if vertices are already linked
+func (c *ConcurrentEuler[V]) LinkWithData(first, second V, data interface{}) bool {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.tree.LinkWithData(first, second, data)
+}
+
+// Cut removes given edge
+// return false if edge is not exist
+func (c *ConcurrentEuler[V]) Cut(first, second V) bool {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.tree.Cut(first, second)
+}
+
+// LinkE creates edge in forest like Euler.LinkE
+func (c *ConcurrentEuler[V]) LinkE(first, second V) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.tree.LinkE(first, second)
+}
+
+// CutE removes given edge like Euler.CutE
+func (c *ConcurrentEuler[V]) CutE(first, second V) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.tree.CutE(first, second)
+}
+
+// Reroot rotates euler tour of v tree so it begins and ends with v
+func (c *ConcurrentEuler[V]) Reroot(v V) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.tree.Reroot(v)
+}
+
+// RemoveVertex cuts all edges of v and forgets it
+//
+// returns false if vertex is unknown
+func (c *ConcurrentEuler[V]) RemoveVertex(v V) bool {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.tree.RemoveVertex(v)
+}
+
+// Compact forgets vertices created by queries and never linked or changed,
+// returns number of removed vertices, queries of strict forest don't create them
+func (c *ConcurrentEuler[V]) Compact() int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.tree.Compact()
+}
+
+// EdgeData returns data of edge
+//
+// returns false if edge is not exist
+func (c *ConcurrentEuler[V]) EdgeData(first, second V) (interface{}, bool) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	return c.tree.EdgeData(first, second)
+}
+
+// ComponentSize returns number of vertices in tree of v,
+// unknown vertex is a tree of one vertex
+func (c *ConcurrentEuler[V]) ComponentSize(v V) int {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	return c.tree.ComponentSize(v)
+}
+
+// SubtreeSize returns number of vertices in subtree of v
+// if tree is rooted so that parent is parent of v
+//
+// returns 0 if there is no edge between v and parent
+func (c *ConcurrentEuler[V]) SubtreeSize(v, parent V) int {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	return c.tree.SubtreeSize(v, parent)
+}
+
+// ComponentOf returns distinct vertices of tree of v in order of euler tour
+func (c *ConcurrentEuler[V]) ComponentOf(v V) []V {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	return c.tree.ComponentOf(v)
+}
+
+// Strings O(N*log(N)) complexity
+func (c *ConcurrentEuler[V]) Strings() []string {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	return c.tree.Strings()
+}
+
+// String representation
+func (c *ConcurrentEuler[V]) String() string {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	return c.tree.String()
+}
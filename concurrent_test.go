@@ -0,0 +1,93 @@
+package euler
+
+import (
+	"math/rand"
+	"sync"
+	"testing"
+)
+
+func TestConcurrentEuler(t *testing.T) {
+	tree := CreateConcurrentEuler()
+
+	if !tree.IsConnected(1, 1) || tree.IsConnected(1, 2) || tree.ComponentSize(1) != 1 ||
+		len(tree.ComponentOf(1)) != 1 || tree.SubtreeSize(1, 2) != 0 {
+		t.Error("wrong queries of unknown vertices")
+	}
+	if len(tree.tree.treaps) != 0 {
+		t.Errorf("queries created vertices %v", tree.Strings())
+	}
+
+	tree.Link(1, 2)
+	tree.LinkWithData(2, 3, "2-3")
+	if !tree.IsConnected(1, 3) || tree.ComponentSize(3) != 3 || tree.SubtreeSize(3, 2) != 1 {
+		t.Errorf("%v: wrong queries", tree.Strings())
+	}
+	if data, ok := tree.EdgeData(3, 2); data != "2-3" || !ok {
+		t.Errorf("%v.EdgeData(3, 2)\nExpected 2-3\nGot %v", tree.Strings(), data)
+	}
+	tree.Reroot(3)
+	if got := tree.String(); got != "3-2-1-2-3" {
+		t.Errorf("Reroot(3)\nExpected 3-2-1-2-3\nGot %v", got)
+	}
+	tree.Cut(1, 2)
+	if tree.IsConnected(1, 3) || len(tree.ComponentOf(2)) != 2 {
+		t.Errorf("%v: wrong queries after cut", tree.Strings())
+	}
+}
+
+// run with -race
+func testConcurrentEulerRandom(t *testing.T, choiceLevelLink, numbers int) {
+	const (
+		goroutines = 8
+		queries    = 2000
+	)
+	tree := CreateConcurrentEuler()
+	for j := 0; j < numbers/2; j++ {
+		tree.Link(rand.Intn(numbers), rand.Intn(numbers))
+	}
+	choiceLevelCut := choiceLevelLink * 2
+
+	var wg sync.WaitGroup
+	for i := 0; i < goroutines; i++ {
+		wg.Add(1)
+		go func(seed int64) {
+			defer wg.Done()
+			random := rand.New(rand.NewSource(seed))
+
+			for j := 0; j < queries; j++ {
+				// queries of unknown vertices too
+				query := testQuery{random.Intn(numbers * 2), random.Intn(numbers * 2)}
+
+				choice := random.Intn(100)
+				if choice < choiceLevelLink {
+					tree.Link(query.a, query.b)
+				} else if choice < choiceLevelCut {
+					tree.Cut(query.a, query.b)
+				} else if choice%2 == 0 {
+					tree.IsConnected(query.a, query.b)
+				} else {
+					tree.ComponentSize(query.a)
+					tree.SubtreeSize(query.a, query.b)
+				}
+			}
+		}(int64(i))
+	}
+	wg.Wait()
+	testValidate(t, tree.tree)
+
+	vertices := 0
+	for _, component := range tree.tree.roots() {
+		vertices += (component.size + 1) / 2
+	}
+	if vertices != len(tree.tree.treaps) {
+		t.Errorf("trees have %v vertices, expected %v", vertices, len(tree.tree.treaps))
+	}
+}
+
+func TestConcurrentEulerRandomThirdRead(t *testing.T) {
+	testConcurrentEulerRandom(t, 33, 1000)
+}
+
+func TestConcurrentEulerRandomOnlyRead(t *testing.T) {
+	testConcurrentEulerRandom(t, 0, 1000)
+}
@@ -0,0 +1,62 @@
+package euler
+
+import "iter"
+
+// TourCursor walks euler tour entries by parent pointers
+// without recursion and allocations
+//
+// cursor is invalid after any change of forest,
+// including queries that split treaps (SubtreeAggregate, SubtreeApply)
+type TourCursor[V comparable] struct {
+	entry *Treap[V]
+}
+
+// Cursor returns cursor at the first entry of euler tour of v tree
+func (tree *Euler[V]) Cursor(v V) TourCursor[V] {
+	return TourCursor[V]{entry: tree.queryTreap(v).Root().leftmost()}
+}
+
+// Tour iterates over vertices of euler tour of v tree
+func (tree *Euler[V]) Tour(v V) iter.Seq[V] {
+	return func(yield func(V) bool) {
+		cursor := tree.Cursor(v)
+		for yield(cursor.Vertex()) && cursor.Next() {
+		}
+	}
+}
+
+// Vertex returns vertex of current entry
+func (c *TourCursor[V]) Vertex() V {
+	return c.entry.vertex
+}
+
+// Index returns position of current entry in tour
+//
+// O(log(N)) complexity
+func (c *TourCursor[V]) Index() int {
+	return c.entry.index()
+}
+
+// Next moves cursor to the next entry
+//
+// returns false and stays at the last entry if there is no next entry
+func (c *TourCursor[V]) Next() bool {
+	next := c.entry.next()
+	if next == nil {
+		return false
+	}
+	c.entry = next
+	return true
+}
+
+// Prev moves cursor to the previous entry
+//
+// returns false and stays at the first entry if there is no previous entry
+func (c *TourCursor[V]) Prev() bool {
+	prev := c.entry.prev()
+	if prev == nil {
+		return false
+	}
+	c.entry = prev
+	return true
+}
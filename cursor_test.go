@@ -0,0 +1,98 @@
+package euler
+
+import (
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestEuler_Tour(t *testing.T) {
+	tree := createTestTreeByLink(
+		[]struct{ a, b int }{
+			{1, 2},
+			{2, 3},
+			{5, 3},
+		},
+		[]int{4},
+	)
+	testValidate(t, tree)
+
+	tests := []struct {
+		v        Vertex
+		expected []Vertex
+	}{
+		{1, []Vertex{5, 3, 2, 1, 2, 3, 5}},
+		{5, []Vertex{5, 3, 2, 1, 2, 3, 5}},
+		{4, []Vertex{4}},
+	}
+
+	for _, test := range tests {
+		got := slices.Collect(tree.Tour(test.v))
+
+		if !reflect.DeepEqual(got, test.expected) {
+			t.Errorf("%v.Tour(%v)\nExpected %v\nGot %v", tree.Strings(), test.v, test.expected, got)
+		}
+		testValidate(t, tree)
+	}
+}
+
+func TestTourCursor(t *testing.T) {
+	tree := createTestTreeByLink(
+		[]struct{ a, b int }{
+			{1, 2},
+			{2, 3},
+		},
+		[]int{},
+	)
+
+	cursor := tree.Cursor(3)
+	if cursor.Prev() {
+		t.Error("Prev at first entry returned true")
+	}
+
+	var forward []Vertex
+	for {
+		forward = append(forward, cursor.Vertex())
+		if cursor.Index() != len(forward)-1 {
+			t.Errorf("Index\nExpected %v\nGot %v", len(forward)-1, cursor.Index())
+		}
+		if !cursor.Next() {
+			break
+		}
+	}
+
+	var backward []Vertex
+	for {
+		backward = append(backward, cursor.Vertex())
+		if !cursor.Prev() {
+			break
+		}
+	}
+	slices.Reverse(backward)
+	testValidate(t, tree)
+
+	expected := []Vertex{1, 2, 3, 2, 1}
+	if !reflect.DeepEqual(forward, expected) || !reflect.DeepEqual(backward, expected) {
+		t.Errorf("%v: cursor\nExpected %v\nGot %v and %v", tree.Strings(), expected, forward, backward)
+	}
+}
+
+func TestEuler_TourLong(t *testing.T) {
+	const numbers = 100000
+	tree := CreateEuler()
+	for i := 1; i < numbers; i++ {
+		tree.Link(i-1, i)
+	}
+	testValidate(t, tree)
+
+	count := 0
+	for v := range tree.Tour(0) {
+		if count == 0 && v != 0 {
+			t.Errorf("Tour should start from 0, got %v", v)
+		}
+		count++
+	}
+	if count != 2*numbers-1 {
+		t.Errorf("Tour length\nExpected %v\nGot %v", 2*numbers-1, count)
+	}
+}
@@ -1,44 +1,130 @@
 package euler
 
 import (
-	"math/rand"
+	"cmp"
+	"errors"
+	"fmt"
+	"iter"
+	"slices"
 	"strings"
-	"sort"
+)
+
+var (
+	// ErrAlreadyConnected vertices of new edge are in one tree
+	ErrAlreadyConnected = errors.New("euler: vertices are already connected")
+	// ErrSelfLoop edge from vertex to itself
+	ErrSelfLoop = errors.New("euler: self loop")
+	// ErrNoEdge edge is not in forest
+	ErrNoEdge = errors.New("euler: edge is not exist")
+	// ErrUnknownVertex vertex is not in forest
+	ErrUnknownVertex = errors.New("euler: unknown vertex")
 )
 
 // Euler structure that allows operations
 //  IsConnected
 //  Link
 //  Cut
-// with O(log(N)) complexity for any forest (acyclic graph) with int vertices
-type Euler struct {
-	treaps map[Vertex]*Treap
-	edges  map[Vertex]map[Vertex]*Edge
+// with O(log(N)) complexity for any forest (acyclic graph) with comparable vertices
+type Euler[V comparable] struct {
+	treaps map[V]*Treap[V]
+	// edges are saved for both directions
+	edges map[V]map[V]*Edge[V]
+	// monoid and action of values, entries have values only if it's set
+	aggregation *aggregation
+	compare     func(a, b V) int
+	// queries don't create unknown vertices
+	strict bool
+	// priority of new entries, global random if nil
+	priority func() int
+}
+
+// CreateEuler making empty tree with int vertices
+func CreateEuler() *Euler[int] {
+	return CreateEulerFunc(cmp.Compare[int])
 }
 
-// CreateEuler making empty tree
-func CreateEuler() *Euler {
-	return &Euler{
-		treaps: make(map[Vertex]*Treap),
-		edges:  make(map[Vertex]map[Vertex]*Edge),
+// CreateEulerFunc making empty tree with any comparable vertices,
+// compare is used for order of trees in Strings,
+// if it's nil, trees are ordered by string representation of vertices
+func CreateEulerFunc[V comparable](compare func(a, b V) int, opts ...Option) *Euler[V] {
+	o := createOptions(opts)
+	return &Euler[V]{
+		treaps:   make(map[V]*Treap[V]),
+		edges:    make(map[V]map[V]*Edge[V]),
+		compare:  compare,
+		strict:   o.strict,
+		priority: o.priority,
 	}
 }
 
 // IsConnected return true if vertices are in one treap
-func (tree *Euler) IsConnected(first, second Vertex) bool {
-	return tree.isConnected(tree.getTreap(first), tree.getTreap(second))
+func (tree *Euler[V]) IsConnected(first, second V) bool {
+	firstTreap, secondTreap := tree.queryTreap(first), tree.queryTreap(second)
+	return first == second || tree.isConnected(firstTreap, secondTreap)
+}
+
+// SetStrict sets mode where queries don't create unknown vertices,
+// unknown vertex is a tree of one vertex for them
+func (tree *Euler[V]) SetStrict(strict bool) {
+	tree.strict = strict
+}
+
+// HasVertex return true if vertex is known,
+// vertices are created by Link, SetValue and queries if forest is not strict
+func (tree *Euler[V]) HasVertex(v V) bool {
+	_, ok := tree.treaps[v]
+	return ok
+}
+
+// Vertices iterates over known vertices in no particular order
+func (tree *Euler[V]) Vertices() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for v := range tree.treaps {
+			if !yield(v) {
+				return
+			}
+		}
+	}
 }
 
 // Link creates edge in forest
 //
 // returns false if vertices are already linked
-func (tree *Euler) Link(first, second Vertex) bool {
+func (tree *Euler[V]) Link(first, second V) bool {
+	return tree.LinkWithData(first, second, nil)
+}
+
+// LinkWithData creates edge with data in forest
+//
+// returns false if vertices are already linked
+func (tree *Euler[V]) LinkWithData(first, second V, data interface{}) bool {
 	firstTreap := tree.getTreap(first)
 	secondTreap := tree.getTreap(second)
 	if tree.isConnected(firstTreap, secondTreap) {
 		return false
 	}
 
+	tree.link(firstTreap, secondTreap, data)
+
+	return true
+}
+
+// LinkE creates edge in forest like Link,
+// returns ErrSelfLoop or ErrAlreadyConnected if edge can't be created
+func (tree *Euler[V]) LinkE(first, second V) error {
+	if first == second {
+		return vertexError(ErrSelfLoop, first, second)
+	}
+	if !tree.Link(first, second) {
+		return vertexError(ErrAlreadyConnected, first, second)
+	}
+	return nil
+}
+
+// link creates edge between entries of different trees
+func (tree *Euler[V]) link(firstTreap, secondTreap *Treap[V], data interface{}) {
+	first, second := firstTreap.vertex, secondTreap.vertex
+
 	// in tree
 	//  {3, 1-2-1}
 	//  link(3, 2)
@@ -51,12 +137,12 @@ func (tree *Euler) Link(first, second Vertex) bool {
 
 	// remove duplicated entry
 	//  {3, 3}  {[1-]2, 2-1} -> {3, 3}  {2, 2-1}
-	var removing *Treap
-	removing, part3 = part3.Split(1).Destruct()
+	var removing *Treap[V]
+	removing, part3 = tree.split(part3, 1).Destruct()
 
 	// relink vertex if needed
 	if tree.getTreap(removing.vertex) == removing {
-		tree.treaps[removing.vertex] = part2.rightmost()
+		tree.setTreap(removing.vertex, part2.rightmost())
 	}
 
 	// save edge for fast cutting
@@ -65,23 +151,22 @@ func (tree *Euler) Link(first, second Vertex) bool {
 	//	firstEdgePart = secondSplit.Second.rightmost()
 	//}
 	secondEdgePart := part4.leftmost()
-	edge := &Edge{
-		firstEdgePart,
-		secondEdgePart,
+	edge := &Edge[V]{
+		First:  firstEdgePart,
+		Second: secondEdgePart,
+		Data:   data,
 	}
 	firstEdgePart.edge = edge
 	secondEdgePart.edge = edge
 	tree.setEdge(first, second, edge)
 
 	//  {3, 3}  {2, 2-1} -> 3-2-1-2-3
-	Merge(Merge(part1, part2), Merge(part3, part4))
-
-	return true
+	tree.merge(tree.merge(part1, part2), tree.merge(part3, part4))
 }
 
 // Cut removes given edge
 // return false if edge is not exist
-func (tree *Euler) Cut(first, second Vertex) bool {
+func (tree *Euler[V]) Cut(first, second V) bool {
 	// in tree
 	//  {3-2-1-2-3}
 	// cut(2, 1)
@@ -115,12 +200,12 @@ func (tree *Euler) Cut(first, second Vertex) bool {
 	//      edge(1, 2)
 	//        | |
 	//  3-(2)  1  2-3
-	var removing *Treap
+	var removing *Treap[V]
 	left, removing = tree.splitByEntry(left.rightmost(), true, false).Destruct()
 
 	// relink vertex if needed
 	if tree.getTreap(removing.vertex) == removing {
-		tree.treaps[removing.vertex] = right.leftmost()
+		tree.setTreap(removing.vertex, right.leftmost())
 	}
 
 	// remove edge and unlink entries from it
@@ -147,79 +232,513 @@ func (tree *Euler) Cut(first, second Vertex) bool {
 	//  edge(2, 3)
 	//       | |
 	//    1 3-2-3
-	Merge(left, right)
+	tree.merge(left, right)
+
+	return true
+}
+
+// CutE removes given edge like Cut,
+// returns ErrSelfLoop, ErrUnknownVertex or ErrNoEdge if edge is not exist
+func (tree *Euler[V]) CutE(first, second V) error {
+	if first == second {
+		return vertexError(ErrSelfLoop, first, second)
+	}
+	for _, v := range []V{first, second} {
+		if !tree.HasVertex(v) {
+			return fmt.Errorf("%w: %s", ErrUnknownVertex, formatVertex(v))
+		}
+	}
+	if !tree.Cut(first, second) {
+		return vertexError(ErrNoEdge, first, second)
+	}
+	return nil
+}
+
+// RemoveVertex cuts all edges of v and forgets it
+//
+// returns false if vertex is unknown,
+// O(D*log(N)) complexity for D edges of v
+func (tree *Euler[V]) RemoveVertex(v V) bool {
+	if _, ok := tree.treaps[v]; !ok {
+		return false
+	}
+
+	neighbours := make([]V, 0, len(tree.edges[v]))
+	for neighbour := range tree.edges[v] {
+		neighbours = append(neighbours, neighbour)
+	}
+	for _, neighbour := range neighbours {
+		tree.Cut(v, neighbour)
+	}
+	delete(tree.treaps, v)
+
+	return true
+}
+
+// Compact forgets vertices created by queries and never linked or changed,
+// they behave like unknown vertices
+//
+// returns number of removed vertices, O(N) complexity
+func (tree *Euler[V]) Compact() int {
+	removed := 0
+	for v, treap := range tree.treaps {
+		if treap.queried {
+			delete(tree.treaps, v)
+			removed++
+		}
+	}
+	return removed
+}
+
+// EdgeData returns data of edge
+//
+// returns false if edge is not exist
+func (tree *Euler[V]) EdgeData(first, second V) (interface{}, bool) {
+	edge := tree.getEdge(first, second)
+	if edge == nil {
+		return nil, false
+	}
+	return edge.Data, true
+}
+
+// Edges iterates over neighbours of v with data of edges in no particular order
+func (tree *Euler[V]) Edges(v V) iter.Seq2[V, interface{}] {
+	return func(yield func(V, interface{}) bool) {
+		for neighbour, edge := range tree.edges[v] {
+			if !yield(neighbour, edge.Data) {
+				return
+			}
+		}
+	}
+}
+
+// Reroot rotates euler tour of v tree so it begins and ends with v
+func (tree *Euler[V]) Reroot(v V) {
+	entry := tree.queryTreap(v)
+	if entry.Root().leftmost().vertex == v {
+		return
+	}
+
+	tree.reroot(entry)
+}
+
+// reroot rotates euler tour so it begins with entry
+func (tree *Euler[V]) reroot(entry *Treap[V]) {
+	if entry.index() == 0 {
+		return
+	}
+
+	// in tree
+	//  1-2-3-2-1
+	//  reroot(3)
+
+	// split with duplicate
+	//  1-2-3-2-1 -> {1-2-3, 3-2-1}
+	left, right := tree.splitByEntry(entry, true, true).Destruct()
+
+	// remove last entry of right side, it's the same vertex as first of left side
+	//  {1-2-3, 3-2-(1)}
+	var removing *Treap[V]
+	right, removing = tree.split(right, right.size-1).Destruct()
+	first := left.leftmost()
+
+	// relink vertex if needed
+	if tree.getTreap(removing.vertex) == removing {
+		tree.setTreap(removing.vertex, first)
+	}
+
+	// change link for edge of removing entry
+	//  edge(1, 2)
+	//   |     |
+	//  1-2-3  3-2-(1)
+	changeEdgeLink(removing, first)
+
+	//  {1-2-3, 3-2} -> 3-2-1-2-3
+	tree.merge(right, left)
+}
+
+// ComponentSize returns number of vertices in tree of v
+func (tree *Euler[V]) ComponentSize(v V) int {
+	return (tree.queryTreap(v).Root().size + 1) / 2
+}
+
+// SubtreeSize returns number of vertices in subtree of v
+// if tree is rooted so that parent is parent of v
+//
+// returns 0 if there is no edge between v and parent
+func (tree *Euler[V]) SubtreeSize(v, parent V) int {
+	edge := tree.getEdge(v, parent)
+	if edge == nil {
+		return 0
+	}
+
+	// entries of edge bound tour of one side
+	//  edge(2, 3)
+	//      | |
+	//  1-2-3-2-1
+	first, second := edge.First, edge.Second
+	firstIndex, secondIndex := first.index(), second.index()
+	if firstIndex > secondIndex {
+		first = second
+		firstIndex, secondIndex = secondIndex, firstIndex
+	}
+	inner := (secondIndex - firstIndex + 1) / 2
+
+	if first.vertex == v {
+		return inner
+	}
+	return tree.ComponentSize(v) - inner
+}
+
+// SetMonoid sets aggregation of vertex values,
+// unset values become monoid identity
+//
+// O(N) complexity
+func (tree *Euler[V]) SetMonoid(monoid Monoid) {
+	previous := tree.aggregation
+	tree.aggregation = &aggregation{monoid: &monoid}
+	if previous != nil {
+		tree.aggregation.action = previous.action
+	}
+	for _, root := range tree.roots() {
+		root.setAggregation(previous, tree.aggregation)
+	}
+}
+
+// SetAction sets lazy update of vertex values
+//
+// O(N) complexity
+func (tree *Euler[V]) SetAction(action Action) {
+	previous := tree.aggregation
+	tree.aggregation = &aggregation{action: &action}
+	if previous != nil {
+		tree.aggregation.monoid = previous.monoid
+	}
+	for _, root := range tree.roots() {
+		root.setAggregation(previous, tree.aggregation)
+	}
+}
+
+// SetValue sets value of vertex
+func (tree *Euler[V]) SetValue(v V, value interface{}) {
+	treap := tree.getTreap(v)
+	treap.pushPath(tree.aggregation)
+	treap.setValue(value)
+	treap.updatePath(tree.aggregation)
+}
+
+// Value returns value of vertex
+func (tree *Euler[V]) Value(v V) interface{} {
+	treap := tree.queryTreap(v)
+	treap.pushPath(tree.aggregation)
+	return treap.getValue()
+}
+
+// ComponentAggregate returns aggregate of values in tree of v,
+// values are combined in order of euler tour
+//
+// monoid should be set
+func (tree *Euler[V]) ComponentAggregate(v V) interface{} {
+	root := tree.queryTreap(v).Root()
+	if root.values == nil {
+		return nil
+	}
+	return root.values.aggregate
+}
+
+// SubtreeAggregate returns aggregate of values in subtree of v
+// if tree is rooted so that parent is parent of v
+//
+// returns monoid identity if there is no edge between v and parent,
+// monoid should be set
+func (tree *Euler[V]) SubtreeAggregate(v, parent V) interface{} {
+	monoid := tree.aggregation.monoid
+	edge := tree.getEdge(v, parent)
+	if edge == nil {
+		return monoid.Identity
+	}
+
+	left, middle, right, inner := tree.splitByEdge(edge, v)
+	result := middle.values.aggregate
+	if !inner {
+		result = monoid.Combine(left.getAggregate(monoid), right.getAggregate(monoid))
+	}
+	tree.merge(tree.merge(left, middle), right)
+
+	return result
+}
+
+// ComponentApply updates values in tree of v by tag of action
+//
+// action should be set
+func (tree *Euler[V]) ComponentApply(v V, tag interface{}) {
+	tree.getTreap(v).Root().apply(tag, tree.aggregation)
+}
+
+// SubtreeApply updates values in subtree of v by tag of action
+// if tree is rooted so that parent is parent of v
+//
+// returns false if there is no edge between v and parent,
+// action should be set
+func (tree *Euler[V]) SubtreeApply(v, parent V, tag interface{}) bool {
+	edge := tree.getEdge(v, parent)
+	if edge == nil {
+		return false
+	}
+
+	left, middle, right, inner := tree.splitByEdge(edge, v)
+	if inner {
+		middle.apply(tag, tree.aggregation)
+	} else {
+		left.apply(tag, tree.aggregation)
+		right.apply(tag, tree.aggregation)
+	}
+	tree.merge(tree.merge(left, middle), right)
 
 	return true
 }
 
+// ComponentOf returns distinct vertices of tree of v in order of euler tour
+//
+// O(size of tree) complexity
+func (tree *Euler[V]) ComponentOf(v V) []V {
+	return tree.queryTreap(v).Root().appendVertices(nil)
+}
+
+// ComponentID returns first vertex of euler tour of v tree,
+// it is the same for all vertices of tree and
+// stays the same while tree is not changed by Link, Cut or Reroot
+func (tree *Euler[V]) ComponentID(v V) V {
+	return tree.queryTreap(v).Root().leftmost().vertex
+}
+
+// Components iterates over vertices of all trees,
+// trees are in the same order as in Strings
+//
+// O(N*log(N)) complexity, forest should not be changed during iteration
+func (tree *Euler[V]) Components() iter.Seq[[]V] {
+	return func(yield func([]V) bool) {
+		for _, root := range tree.roots() {
+			if !yield(root.appendVertices(nil)) {
+				return
+			}
+		}
+	}
+}
+
 // Strings O(N*log(N)) complexity
-func (tree *Euler) Strings() (result []string) {
-	uniqueTreapMap := make(map[int]*Treap, len(tree.treaps))
+func (tree *Euler[V]) Strings() (result []string) {
+	for _, root := range tree.roots() {
+		result = append(result, root.Stringify())
+	}
+
+	return
+}
+
+// String representation
+func (tree *Euler[V]) String() string {
+	return strings.Join(tree.Strings(), "\n")
+}
+
+// roots returns roots of all treaps sorted by their vertices
+func (tree *Euler[V]) roots() []*Treap[V] {
+	uniqueTreapMap := make(map[V]*Treap[V], len(tree.treaps))
 	for _, treap := range tree.treaps {
 		root := treap.Root()
 		uniqueTreapMap[root.vertex] = root
 	}
 
-	keys := make([]int, 0, len(uniqueTreapMap))
+	keys := make([]V, 0, len(uniqueTreapMap))
 	for key := range uniqueTreapMap {
 		keys = append(keys, key)
 	}
-	sort.Ints(keys)
+	if tree.compare != nil {
+		slices.SortFunc(keys, tree.compare)
+	} else {
+		slices.SortFunc(keys, func(a, b V) int {
+			return strings.Compare(formatVertex(a), formatVertex(b))
+		})
+	}
 
+	result := make([]*Treap[V], 0, len(keys))
 	for _, key := range keys {
-		result = append(result, uniqueTreapMap[key].Stringify())
+		result = append(result, uniqueTreapMap[key])
 	}
 
-	return
+	return result
 }
 
-// String representation
-func (tree *Euler) String() string {
-	return strings.Join(tree.Strings(), "\n")
+// vertexError adds vertices of edge to err
+func vertexError[V comparable](err error, first, second V) error {
+	return fmt.Errorf("%w: %s-%s", err, formatVertex(first), formatVertex(second))
 }
 
-func (tree *Euler) isConnected(first, second *Treap) bool {
+func (tree *Euler[V]) isConnected(first, second *Treap[V]) bool {
 	return first.Root() == second.Root()
 }
 
-func (tree *Euler) getTreap(v Vertex) *Treap {
+// getTreap returns entry linked from Euler for changes of forest,
+// vertex is not forgotten by Compact after it
+func (tree *Euler[V]) getTreap(v V) *Treap[V] {
 	result, ok := tree.treaps[v]
 	if !ok {
-		result = &Treap{priority: rand.Int(), size: 1, vertex: v}
+		result = tree.createDetachedTreap(v)
+		result.priority = tree.nextPriority()
 		tree.treaps[v] = result
 	}
+	result.queried = false
 	return result
 }
 
-func (tree *Euler) setEdge(first, second Vertex, edge *Edge) {
-	edgesMap, key := tree.getEdgesMap(first, second)
-	edgesMap[key] = edge
+// queryTreap returns entry linked from Euler,
+// in strict mode unknown vertex gets detached entry that is not saved
+func (tree *Euler[V]) queryTreap(v V) *Treap[V] {
+	if result, ok := tree.treaps[v]; ok {
+		return result
+	}
+	if tree.strict {
+		return tree.createDetachedTreap(v)
+	}
+	result := tree.getTreap(v)
+	result.queried = true
+	return result
+}
+
+// createDetachedTreap creates own entry of isolated vertex,
+// entry gets priority only when it's saved, so unsaved entries don't change priorities of forest
+func (tree *Euler[V]) createDetachedTreap(v V) *Treap[V] {
+	result := tree.newTreap(v, 0)
+	result.own = true
+	if a := tree.aggregation; a != nil {
+		if a.monoid != nil {
+			result.values.value = a.monoid.Identity
+		}
+		result.updateSize(a)
+	}
+	return result
+}
+
+func (tree *Euler[V]) createTreap(v V) *Treap[V] {
+	return tree.newTreap(v, tree.nextPriority())
+}
+
+func (tree *Euler[V]) newTreap(v V, priority int) *Treap[V] {
+	result := &Treap[V]{
+		priority: priority,
+		size:     1,
+		vertex:   v,
+	}
+	if tree.aggregation != nil {
+		result.values = &treapValues{}
+	}
+	return result
+}
+
+// setTreap relinks vertex to another entry, value and marks of vertex move with it
+func (tree *Euler[V]) setTreap(v V, t *Treap[V]) {
+	old, ok := tree.treaps[v]
+	tree.treaps[v] = t
+	if !ok || old == t {
+		return
+	}
+	a := tree.aggregation
+	old.pushPath(a)
+	t.pushPath(a)
+	t.own, t.marks = true, old.marks
+	old.own, old.marks = false, 0
+	if a == nil {
+		// values of forest without aggregation have only value of own entry
+		t.values, old.values = old.values, nil
+	} else {
+		t.values.value, old.values.value = old.values.value, nil
+	}
+	if a != nil || t.marks != 0 {
+		old.updatePath(a)
+		t.updatePath(a)
+	}
+}
+
+// setMark sets or clears mark of vertex
+func (tree *Euler[V]) setMark(v V, mark uint8, on bool) {
+	treap := tree.getTreap(v)
+	if on {
+		treap.marks |= mark
+	} else {
+		treap.marks &^= mark
+	}
+	treap.updatePath(tree.aggregation)
 }
 
-func (tree *Euler) getEdge(first, second Vertex) *Edge {
-	edgesMap, key := tree.getEdgesMap(first, second)
-	return edgesMap[key]
+// markedOf returns vertices with mark in the tree of v in order of euler tour
+//
+// O(K*log(N)) complexity for K marked vertices
+func (tree *Euler[V]) markedOf(v V, mark uint8) []V {
+	return tree.queryTreap(v).Root().appendMarked(mark, nil)
 }
 
-func (tree *Euler) removeEdge(first, second Vertex) {
-	edgesMap, key := tree.getEdgesMap(first, second)
-	delete(edgesMap, key)
+// findMarked returns any vertex with mark in the tree of v
+func (tree *Euler[V]) findMarked(v V, mark uint8) (V, bool) {
+	found := tree.getTreap(v).Root().findMarked(mark)
+	if found == nil {
+		var zero V
+		return zero, false
+	}
+	return found.vertex, true
 }
 
-func (tree *Euler) getEdgesMap(first, second Vertex) (map[Vertex]*Edge, int) {
-	// first should be smaller
-	if first > second {
-		first, second = second, first
+func (tree *Euler[V]) setEdge(first, second V, edge *Edge[V]) {
+	tree.getEdgesMap(first)[second] = edge
+	tree.getEdgesMap(second)[first] = edge
+}
+
+func (tree *Euler[V]) getEdge(first, second V) *Edge[V] {
+	return tree.edges[first][second]
+}
+
+func (tree *Euler[V]) removeEdge(first, second V) {
+	tree.removeHalfEdge(first, second)
+	tree.removeHalfEdge(second, first)
+}
+
+func (tree *Euler[V]) removeHalfEdge(from, to V) {
+	edgesMap := tree.edges[from]
+	delete(edgesMap, to)
+	if len(edgesMap) == 0 {
+		delete(tree.edges, from)
 	}
+}
 
-	edgesMap, ok := tree.edges[first]
+func (tree *Euler[V]) getEdgesMap(v V) map[V]*Edge[V] {
+	edgesMap, ok := tree.edges[v]
 	// init if needed
 	if !ok {
-		edgesMap = make(map[Vertex]*Edge)
-		tree.edges[first] = edgesMap
+		edgesMap = make(map[V]*Edge[V])
+		tree.edges[v] = edgesMap
 	}
 
-	return edgesMap, second
+	return edgesMap
+}
+
+// splitByEdge splits tour by entries of edge,
+// middle part is tour of subtree of one side of edge
+//  edge(2, 3)
+//      | |
+//  1-2-3-2-1 >> {1-2, 3, 2-1}
+//
+// inner is true if v is in middle part
+func (tree *Euler[V]) splitByEdge(edge *Edge[V], v V) (left, middle, right *Treap[V], inner bool) {
+	first, second := edge.First, edge.Second
+	firstIndex, secondIndex := first.index(), second.index()
+	if firstIndex > secondIndex {
+		first = second
+		firstIndex, secondIndex = secondIndex, firstIndex
+	}
+	inner = first.vertex == v
+
+	left, middle = tree.split(first.Root(), firstIndex).Destruct()
+	middle, right = tree.split(middle, secondIndex-firstIndex).Destruct()
+
+	return
 }
 
 // splitByEntry split treap by two parts
@@ -239,52 +758,54 @@ func (tree *Euler) getEdgesMap(first, second Vertex) (map[Vertex]*Edge, int) {
 //    2                                      2  2 <- dup
 //   / \   >> split by 2 left with dup >>   /    \
 //  1   1                                  1      1
-func (tree *Euler) splitByEntry(
-	entry *Treap,
+func (tree *Euler[V]) splitByEntry(
+	entry *Treap[V],
 	splitToRight,
 	makeDuplicate bool,
-) TreapPair {
+) TreapPair[V] {
 	// k - number of entries in left side from entry
-	k := entry.left.getSize()
-	current := entry
-	for current.parent != nil {
-		if current.parent.right == current {
-			k += current.parent.left.getSize() + 1
-		}
-		current = current.parent
-	}
-
+	k := entry.index()
 	if !splitToRight {
 		// also take entry to left
 		k++
 	}
-	result := current.Split(k)
+	result := tree.split(entry.Root(), k)
 
 	if makeDuplicate {
 		// add duplicate to opposite side
 		if splitToRight {
-			result.First = Merge(result.First, tree.duplicateTreap(entry, true))
+			result.First = tree.merge(result.First, tree.duplicateTreap(entry, true))
 		} else {
 			// we don't need to relink edge if split moves left and duplicate moves to right
 			// because it will have another edge
-			result.Second = Merge(tree.duplicateTreap(entry, false), result.Second)
+			result.Second = tree.merge(tree.duplicateTreap(entry, false), result.Second)
 		}
 	}
 
 	return result
 }
 
+// split splits treap with aggregation of forest
+func (tree *Euler[V]) split(t *Treap[V], k int) TreapPair[V] {
+	return t.split(k, tree.aggregation)
+}
+
+// merge merges treaps with aggregation of forest
+func (tree *Euler[V]) merge(first, second *Treap[V]) *Treap[V] {
+	return merge(first, second, tree.aggregation)
+}
+
 // duplication relink vertex [and edge] in Euler struct
-func (tree *Euler) duplicateTreap(t *Treap, relinkEdge bool) *Treap {
-	result := &Treap{priority: rand.Int(), size: 1, vertex: t.vertex}
-	tree.treaps[t.vertex] = result
+func (tree *Euler[V]) duplicateTreap(t *Treap[V], relinkEdge bool) *Treap[V] {
+	result := tree.createTreap(t.vertex)
+	tree.setTreap(t.vertex, result)
 	if relinkEdge {
 		changeEdgeLink(t, result)
 	}
 	return result
 }
 
-func changeEdgeLink(from *Treap, to *Treap) {
+func changeEdgeLink[V comparable](from *Treap[V], to *Treap[V]) {
 	if from.edge != nil {
 		if from.edge.First == from {
 			from.edge.First = to
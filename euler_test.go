@@ -1,19 +1,22 @@
 package euler
 
 import (
+	"errors"
 	"testing"
 	"reflect"
+	"slices"
+	"strings"
 )
 
 // for testing, not benchmarking
-func createTestTree(allIndices [][]int) *Euler {
+func createTestTree(allIndices [][]int) *Euler[int] {
 	tree := CreateEuler()
 
 	for _, indices := range allIndices {
-		treaps := make([]*Treap, len(indices))
-		var treap *Treap
+		treaps := make([]*Treap[int], len(indices))
+		var treap *Treap[int]
 		for i, index := range indices {
-			treaps[i] = &Treap{priority: index, size: 1, vertex: index}
+			treaps[i] = &Treap[int]{priority: index, size: 1, vertex: index}
 			tree.treaps[index] = treaps[i]
 			treap = Merge(treap, treaps[i])
 		}
@@ -24,7 +27,7 @@ func createTestTree(allIndices [][]int) *Euler {
 
 func TestEuler_String(t *testing.T) {
 	tests := []struct {
-		tree     *Euler
+		tree     *Euler[int]
 		expected string
 	}{
 		{
@@ -60,7 +63,7 @@ func TestEuler_String(t *testing.T) {
 
 func TestEuler_IsConnected(t *testing.T) {
 	tests := []struct {
-		tree          *Euler
+		tree          *Euler[int]
 		first, second Vertex
 		expected      bool
 	}{
@@ -112,7 +115,7 @@ func TestEuler_IsConnected(t *testing.T) {
 
 func TestEuler_Link(t *testing.T) {
 	tests := []struct {
-		tree           *Euler
+		tree           *Euler[int]
 		first, second  Vertex
 		expectedTree   []string
 		expectedResult bool
@@ -211,7 +214,7 @@ func TestEuler_Link(t *testing.T) {
 	}
 }
 
-func createTestTreeByLink(edges []struct{ a, b int }, vertices []int) *Euler {
+func createTestTreeByLink(edges []struct{ a, b int }, vertices []int) *Euler[int] {
 	tree := CreateEuler()
 
 	for _, edge := range edges {
@@ -230,7 +233,7 @@ func createTestTreeByLink(edges []struct{ a, b int }, vertices []int) *Euler {
 
 func TestEuler_Cut(t *testing.T) {
 	tests := []struct {
-		tree           *Euler
+		tree           *Euler[int]
 		first, second  Vertex
 		expectedTree   []string
 		expectedResult bool
@@ -350,7 +353,7 @@ func alarm(t *testing.T, name string, was []string, first, second Vertex, expect
 	)
 }
 
-func testIsConnected(t *testing.T, tree *Euler, first, second Vertex, expected bool) {
+func testIsConnected(t *testing.T, tree *Euler[int], first, second Vertex, expected bool) {
 	got := tree.IsConnected(first, second)
 
 	if !reflect.DeepEqual(got, expected) {
@@ -358,24 +361,26 @@ func testIsConnected(t *testing.T, tree *Euler, first, second Vertex, expected b
 	}
 }
 
-func testLink(t *testing.T, tree *Euler, first, second Vertex, expected []string) {
+func testLink(t *testing.T, tree *Euler[int], first, second Vertex, expected []string) {
 	was := tree.Strings()
 	if !tree.Link(first, second) {
 		panic("invalid test")
 	}
 	got := tree.Strings()
+	testValidate(t, tree)
 
 	if !reflect.DeepEqual(got, expected) {
 		alarm(t, "Link", was, first, second, expected, got)
 	}
 }
 
-func testCut(t *testing.T, tree *Euler, first, second Vertex, expected []string) {
+func testCut(t *testing.T, tree *Euler[int], first, second Vertex, expected []string) {
 	was := tree.Strings()
 	if !tree.Cut(first, second) {
 		panic("invalid test")
 	}
 	got := tree.Strings()
+	testValidate(t, tree)
 
 	if !reflect.DeepEqual(got, expected) {
 		alarm(t, "Cut", was, first, second, expected, got)
@@ -405,3 +410,531 @@ func TestEuler(t *testing.T) {
 	testIsConnected(t, tree, 1, 4, true)
 	testIsConnected(t, tree, 3, 5, false)
 }
+
+func TestEuler_SubtreeSize(t *testing.T) {
+	// 5-3-2-1-2-3-4-3-5
+	tree := createTestTreeByLink(
+		[]struct{ a, b int }{
+			{1, 2},
+			{3, 4},
+			{2, 3},
+			{5, 3},
+		},
+		[]int{6},
+	)
+
+	tests := []struct {
+		v, parent Vertex
+		expected  int
+	}{
+		{1, 2, 1},
+		{2, 1, 4},
+		{2, 3, 2},
+		{3, 2, 3},
+		{3, 5, 4},
+		{5, 3, 1},
+		{4, 3, 1},
+		{3, 4, 4},
+		{1, 3, 0},
+		{6, 1, 0},
+	}
+
+	for _, test := range tests {
+		got := tree.SubtreeSize(test.v, test.parent)
+
+		if got != test.expected {
+			alarm(t, "SubtreeSize", tree.Strings(), test.v, test.parent, test.expected, got)
+		}
+	}
+}
+
+func TestEuler_ComponentSize(t *testing.T) {
+	tree := createTestTreeByLink(
+		[]struct{ a, b int }{
+			{1, 2},
+			{3, 4},
+			{2, 3},
+		},
+		[]int{5},
+	)
+
+	for v, expected := range map[Vertex]int{1: 4, 4: 4, 5: 1} {
+		if got := tree.ComponentSize(v); got != expected {
+			t.Errorf("%v.ComponentSize(%v)\nExpected %v\nGot %v", tree.Strings(), v, expected, got)
+		}
+	}
+}
+
+func TestEuler_Reroot(t *testing.T) {
+	tests := []struct {
+		tree     *Euler[int]
+		v        Vertex
+		expected []string
+	}{
+		{
+			createTestTreeByLink(
+				[]struct{ a, b int }{},
+				[]int{1},
+			),
+			1,
+			[]string{"1"},
+		},
+		{
+			createTestTreeByLink(
+				[]struct{ a, b int }{
+					{1, 2},
+				},
+				[]int{},
+			),
+			2,
+			[]string{"2-1-2"},
+		},
+		{
+			createTestTreeByLink(
+				[]struct{ a, b int }{
+					{1, 2},
+					{2, 3},
+				},
+				[]int{},
+			),
+			1,
+			[]string{"1-2-3-2-1"},
+		},
+		{
+			createTestTreeByLink(
+				[]struct{ a, b int }{
+					{1, 2},
+					{2, 3},
+				},
+				[]int{},
+			),
+			3,
+			[]string{"3-2-1-2-3"},
+		},
+		{
+			createTestTreeByLink(
+				[]struct{ a, b int }{
+					{1, 2},
+					{3, 4},
+					{2, 3},
+					{5, 3},
+				},
+				[]int{},
+			),
+			4,
+			[]string{"4-3-5-3-2-1-2-3-4"},
+		},
+	}
+
+	for _, test := range tests {
+		was := test.tree.Strings()
+		test.tree.Reroot(test.v)
+		testValidate(t, test.tree)
+		got := test.tree.Strings()
+
+		if !reflect.DeepEqual(got, test.expected) {
+			t.Errorf("%v.Reroot(%v)\nExpected '%v'\nGot '%v'", was, test.v, test.expected, got)
+		}
+	}
+}
+
+func TestEuler_RerootCut(t *testing.T) {
+	tree := CreateEuler()
+
+	testLink(t, tree, 1, 2, []string{"1-2-1"})
+	testLink(t, tree, 2, 3, []string{"1-2-3-2-1"})
+	testLink(t, tree, 3, 4, []string{"1-2-3-4-3-2-1"})
+
+	tree.Reroot(3)
+	testIsConnected(t, tree, 1, 4, true)
+
+	// edges should follow entries after reroot
+	testCut(t, tree, 1, 2, []string{"1", "3-2-3-4-3"})
+	testCut(t, tree, 3, 4, []string{"1", "3-2-3", "4"})
+	testLink(t, tree, 1, 3, []string{"1-3-2-3-1", "4"})
+}
+
+var sumMonoid = Monoid{
+	Identity: 0,
+	Combine: func(a, b interface{}) interface{} {
+		return a.(int) + b.(int)
+	},
+}
+
+func TestEuler_Aggregate(t *testing.T) {
+	// 5-3-2-1-2-3-4-3-5
+	tree := createTestTreeByLink(
+		[]struct{ a, b int }{
+			{1, 2},
+			{3, 4},
+			{2, 3},
+			{5, 3},
+		},
+		[]int{6},
+	)
+	tree.SetValue(1, 1)
+	tree.SetMonoid(sumMonoid)
+	for _, v := range []Vertex{2, 3, 4, 5, 6} {
+		tree.SetValue(v, v)
+	}
+	testValidate(t, tree)
+
+	componentTests := map[Vertex]int{1: 15, 4: 15, 6: 6}
+	for v, expected := range componentTests {
+		if got := tree.ComponentAggregate(v); got != expected {
+			t.Errorf("%v.ComponentAggregate(%v)\nExpected %v\nGot %v", tree.Strings(), v, expected, got)
+		}
+	}
+
+	subtreeTests := []struct {
+		v, parent Vertex
+		expected  int
+	}{
+		{1, 2, 1},
+		{2, 1, 14},
+		{2, 3, 3},
+		{3, 2, 12},
+		{3, 5, 10},
+		{5, 3, 5},
+		{1, 3, 0},
+	}
+	for _, test := range subtreeTests {
+		was := tree.Strings()
+		got := tree.SubtreeAggregate(test.v, test.parent)
+		testValidate(t, tree)
+
+		if got != test.expected {
+			alarm(t, "SubtreeAggregate", was, test.v, test.parent, test.expected, got)
+		}
+		if now := tree.Strings(); !reflect.DeepEqual(was, now) {
+			alarm(t, "SubtreeAggregate", was, test.v, test.parent, was, now)
+		}
+	}
+
+	// values follow vertices on link and cut
+	testCut(t, tree, 2, 3, []string{"2-1-2", "5-3-4-3-5", "6"})
+	if !tree.Link(6, 1) {
+		t.Fatal("Link(6, 1) returned false")
+	}
+	testValidate(t, tree)
+	for v, expected := range map[Vertex]int{1: 9, 3: 12} {
+		if got := tree.ComponentAggregate(v); got != expected {
+			t.Errorf("%v.ComponentAggregate(%v)\nExpected %v\nGot %v", tree.Strings(), v, expected, got)
+		}
+	}
+}
+
+// addAction adds tag to each value
+var addAction = Action{
+	Apply: func(tag, aggregate interface{}, count int) interface{} {
+		return aggregate.(int) + tag.(int)*count
+	},
+	Compose: func(outer, inner interface{}) interface{} {
+		return outer.(int) + inner.(int)
+	},
+}
+
+func TestEuler_Apply(t *testing.T) {
+	// 5-3-2-1-2-3-4-3-5
+	tree := createTestTreeByLink(
+		[]struct{ a, b int }{
+			{1, 2},
+			{3, 4},
+			{2, 3},
+			{5, 3},
+		},
+		[]int{6},
+	)
+	tree.SetMonoid(sumMonoid)
+	tree.SetAction(addAction)
+
+	testApply := func(v, parent Vertex, tag int, expected map[Vertex]int) {
+		was := tree.Strings()
+		if parent == v {
+			tree.ComponentApply(v, tag)
+		} else if !tree.SubtreeApply(v, parent, tag) {
+			alarm(t, "SubtreeApply", was, v, parent, true, false)
+		}
+		testValidate(t, tree)
+		for u, value := range expected {
+			if got := tree.Value(u); got != value {
+				alarm(t, "Value after apply", was, v, parent, expected, map[Vertex]interface{}{u: got})
+			}
+		}
+	}
+
+	testApply(1, 1, 1, map[Vertex]int{1: 1, 2: 1, 3: 1, 4: 1, 5: 1, 6: 0})
+	testApply(2, 3, 10, map[Vertex]int{1: 11, 2: 11, 3: 1, 4: 1, 5: 1})
+	testApply(3, 2, 100, map[Vertex]int{1: 11, 2: 11, 3: 101, 4: 101, 5: 101})
+	testApply(3, 5, 1000, map[Vertex]int{1: 1011, 2: 1011, 3: 1101, 4: 1101, 5: 101})
+
+	if tree.SubtreeApply(1, 3, 1) {
+		t.Error("SubtreeApply without edge returned true")
+	}
+	if got := tree.SubtreeAggregate(2, 3); got != 2022 {
+		alarm(t, "SubtreeAggregate", tree.Strings(), 2, 3, 2022, got)
+	}
+
+	// pending updates and values follow vertices on cut, link and reroot
+	testCut(t, tree, 2, 3, []string{"2-1-2", "5-3-4-3-5", "6"})
+	tree.ComponentApply(1, 1)
+	tree.Reroot(4)
+	if !tree.Link(6, 4) {
+		t.Fatal("Link(6, 4) returned false")
+	}
+	tree.ComponentApply(6, 1)
+	testValidate(t, tree)
+	expected := map[Vertex]int{1: 1012, 2: 1012, 3: 1102, 4: 1102, 5: 102, 6: 1}
+	for v, value := range expected {
+		if got := tree.Value(v); got != value {
+			t.Errorf("%v.Value(%v)\nExpected %v\nGot %v", tree.Strings(), v, value, got)
+		}
+	}
+	if got := tree.ComponentAggregate(3); got != 2307 {
+		t.Errorf("%v.ComponentAggregate(3)\nExpected 2307\nGot %v", tree.Strings(), got)
+	}
+}
+
+func TestEuler_ValueWithoutMonoid(t *testing.T) {
+	tree := createTestTreeByLink(
+		[]struct{ a, b int }{
+			{1, 2},
+			{2, 3},
+		},
+		[]int{},
+	)
+	tree.SetValue(2, 20)
+	tree.Reroot(3)
+	testCut(t, tree, 1, 2, []string{"1", "3-2-3"})
+	tree.Link(2, 4)
+	testValidate(t, tree)
+
+	if got := tree.Value(2); got != 20 {
+		t.Errorf("%v.Value(2)\nExpected 20\nGot %v", tree.Strings(), got)
+	}
+	// entries get values only with monoid or action
+	for entry := tree.treaps[2].Root().leftmost(); entry != nil; entry = entry.next() {
+		if entry.values != nil && entry != tree.treaps[2] {
+			t.Errorf("%v: entry of %v has values", tree.Strings(), entry.vertex)
+		}
+	}
+
+	tree.SetMonoid(sumMonoid)
+	testValidate(t, tree)
+	if got := tree.ComponentAggregate(4); got != 20 {
+		t.Errorf("%v.ComponentAggregate(4)\nExpected 20\nGot %v", tree.Strings(), got)
+	}
+}
+
+func TestEuler_Generic(t *testing.T) {
+	tree := CreateEulerFunc(strings.Compare)
+
+	tree.Link("b", "c")
+	if !tree.IsConnected("c", "b") || tree.IsConnected("a", "b") {
+		t.Errorf("%v: wrong connectivity", tree.Strings())
+	}
+	if got := tree.String(); got != "a\nb-c-b" {
+		t.Errorf("Expected 'a\nb-c-b'\nGot '%v'", got)
+	}
+
+	type point struct{ x, y int }
+	points := CreateEulerFunc[point](nil)
+	points.Link(point{1, 2}, point{3, 4})
+	if got := points.String(); got != "{1 2}-{3 4}-{1 2}" {
+		t.Errorf("Expected '{1 2}-{3 4}-{1 2}'\nGot '%v'", got)
+	}
+}
+
+func TestEuler_EdgeData(t *testing.T) {
+	tree := CreateEuler()
+	tree.LinkWithData(1, 2, "1-2")
+	tree.LinkWithData(3, 2, "3-2")
+	tree.LinkWithData(2, 4, 24)
+	tree.Link(4, 5)
+	tree.Reroot(5)
+	tree.Cut(3, 2)
+
+	tests := []struct {
+		first, second Vertex
+		expected      interface{}
+		expectedOk    bool
+	}{
+		{1, 2, "1-2", true},
+		{2, 1, "1-2", true},
+		{4, 2, 24, true},
+		{4, 5, nil, true},
+		{2, 3, nil, false},
+		{1, 4, nil, false},
+	}
+	for _, test := range tests {
+		got, ok := tree.EdgeData(test.first, test.second)
+
+		if got != test.expected || ok != test.expectedOk {
+			alarm(t, "EdgeData", tree.Strings(), test.first, test.second, test.expected, got)
+		}
+	}
+
+	got := make(map[Vertex]interface{})
+	for neighbour, data := range tree.Edges(2) {
+		got[neighbour] = data
+	}
+	expected := map[Vertex]interface{}{1: "1-2", 4: 24}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("%v.Edges(2)\nExpected %v\nGot %v", tree.Strings(), expected, got)
+	}
+}
+
+func TestEuler_Components(t *testing.T) {
+	tree := createTestTreeByLink(
+		[]struct{ a, b int }{
+			{1, 2},
+			{3, 4},
+			{2, 5},
+		},
+		[]int{6},
+	)
+
+	var got [][]Vertex
+	for component := range tree.Components() {
+		slices.Sort(component)
+		got = append(got, component)
+	}
+	slices.SortFunc(got, slices.Compare)
+	expected := [][]Vertex{{1, 2, 5}, {3, 4}, {6}}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("%v.Components()\nExpected %v\nGot %v", tree.Strings(), expected, got)
+	}
+
+	component := tree.ComponentOf(5)
+	slices.Sort(component)
+	if !reflect.DeepEqual(component, []Vertex{1, 2, 5}) {
+		t.Errorf("%v.ComponentOf(5)\nExpected [1 2 5]\nGot %v", tree.Strings(), component)
+	}
+
+	if tree.ComponentID(1) != tree.ComponentID(5) || tree.ComponentID(1) == tree.ComponentID(3) {
+		t.Errorf("%v: wrong ComponentID", tree.Strings())
+	}
+	tree.Reroot(5)
+	if id := tree.ComponentID(2); id != 5 {
+		t.Errorf("%v.ComponentID(2)\nExpected 5\nGot %v", tree.Strings(), id)
+	}
+}
+
+func TestEuler_RemoveVertex(t *testing.T) {
+	tree := createTestTreeByLink(
+		[]struct{ a, b int }{
+			{1, 2},
+			{2, 3},
+			{2, 4},
+			{4, 5},
+		},
+		nil,
+	)
+
+	if !tree.RemoveVertex(2) {
+		t.Error("RemoveVertex(2) returned false")
+	}
+	testValidate(t, tree)
+	if tree.RemoveVertex(2) {
+		t.Error("RemoveVertex of removed vertex returned true")
+	}
+	testValidate(t, tree)
+
+	expected := []string{"1", "3", "4-5-4"}
+	if got := sortedStrings(tree); !reflect.DeepEqual(got, expected) {
+		t.Errorf("RemoveVertex(2)\nExpected %v\nGot %v", expected, got)
+	}
+	if _, ok := tree.edges[2]; ok {
+		t.Error("edges of removed vertex are not removed")
+	}
+}
+
+func TestEuler_Compact(t *testing.T) {
+	tree := CreateEuler()
+	tree.SetMonoid(sumMonoid)
+	tree.Link(1, 2)
+	tree.Link(2, 3)
+	tree.Cut(2, 3)
+	tree.IsConnected(4, 5)
+	tree.SetValue(6, 10)
+	testValidate(t, tree)
+
+	// linked and cut vertex is kept
+	if got := tree.Compact(); got != 2 {
+		t.Errorf("Compact()\nExpected 2\nGot %v", got)
+	}
+	testValidate(t, tree)
+
+	expected := []string{"1-2-1", "3", "6"}
+	if got := sortedStrings(tree); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Compact()\nExpected %v\nGot %v", expected, got)
+	}
+
+	// queried vertex is kept after link
+	tree.IsConnected(7, 8)
+	tree.Link(7, 1)
+	tree.Cut(7, 1)
+	if got := tree.Compact(); got != 1 || !tree.HasVertex(7) || tree.HasVertex(8) {
+		t.Errorf("Compact() after Link\nExpected 1 and vertex 7\nGot %v, %v", got, tree.Strings())
+	}
+	testValidate(t, tree)
+}
+
+func TestEuler_Strict(t *testing.T) {
+	tree := CreateEuler()
+	tree.SetStrict(true)
+	tree.Link(1, 2)
+
+	testIsConnected(t, tree, 3, 3, true)
+	testIsConnected(t, tree, 1, 3, false)
+	testIsConnected(t, tree, 1, 2, true)
+	if got := tree.ComponentSize(3); got != 1 {
+		t.Errorf("ComponentSize(3)\nExpected 1\nGot %v", got)
+	}
+	if got := tree.ComponentOf(3); !reflect.DeepEqual(got, []Vertex{3}) {
+		t.Errorf("ComponentOf(3)\nExpected [3]\nGot %v", got)
+	}
+	tree.Reroot(4)
+	for range tree.Tour(5) {
+	}
+	testIsConnected(t, tree, 6, 6, true)
+	testIsConnected(t, tree, 6, 7, false)
+	testValidate(t, tree)
+
+	if tree.HasVertex(3) || !tree.HasVertex(1) {
+		t.Error("HasVertex returned wrong value")
+	}
+	vertices := slices.Sorted(tree.Vertices())
+	if !reflect.DeepEqual(vertices, []Vertex{1, 2}) {
+		t.Errorf("Vertices()\nExpected [1 2]\nGot %v", vertices)
+	}
+}
+
+func TestEuler_LinkECutE(t *testing.T) {
+	tree := CreateEuler()
+	tree.Link(1, 2)
+	tree.Link(3, 4)
+
+	tests := []struct {
+		name          string
+		operation     func(first, second Vertex) error
+		first, second Vertex
+		expected      error
+	}{
+		{"LinkE", tree.LinkE, 2, 3, nil},
+		{"LinkE", tree.LinkE, 5, 5, ErrSelfLoop},
+		{"LinkE", tree.LinkE, 1, 4, ErrAlreadyConnected},
+		{"CutE", tree.CutE, 2, 3, nil},
+		{"CutE", tree.CutE, 2, 3, ErrNoEdge},
+		{"CutE", tree.CutE, 1, 1, ErrSelfLoop},
+		{"CutE", tree.CutE, 1, 6, ErrUnknownVertex},
+	}
+	for _, test := range tests {
+		was := tree.Strings()
+		got := test.operation(test.first, test.second)
+
+		if !errors.Is(got, test.expected) || (got == nil) != (test.expected == nil) {
+			alarm(t, test.name, was, test.first, test.second, test.expected, got)
+		}
+	}
+}
@@ -0,0 +1,261 @@
+package euler
+
+import "slices"
+
+// marks of vertices in forests of DynamicGraph
+const (
+	// vertex has tree edges of the forest level
+	treeEdgeMark uint8 = 1 << iota
+	// vertex has non-tree edges of the forest level
+	nonTreeEdgeMark
+)
+
+// DynamicGraph structure that allows operations
+//  IsConnected
+//  AddEdge
+//  RemoveEdge
+// for any undirected graph with comparable vertices (Holm, de Lichtenberg, Thorup)
+// with O(log(N)) complexity of IsConnected and O(log^2(N)) amortized complexity of updates
+type DynamicGraph[V comparable] struct {
+	// levels[i] contains spanning forest of edges with level >= i
+	levels []*graphLevel[V]
+	// edges are saved for both directions
+	edges map[V]map[V]*graphEdge
+	// options of forests of levels
+	options []Option
+	// bridges of searched components by their vertices, updates drop components of their vertices
+	bridges map[V]*bridgeSearch[V]
+}
+
+type graphEdge struct {
+	level  int
+	isTree bool
+}
+
+type adjacency[V comparable] map[V]map[V]struct{}
+
+// graphLevel spanning forest and edges of one level
+type graphLevel[V comparable] struct {
+	forest        *Euler[V]
+	tree, nonTree adjacency[V]
+}
+
+// CreateDynamicGraph making empty graph with int vertices
+func CreateDynamicGraph(opts ...Option) *DynamicGraph[int] {
+	return CreateDynamicGraphOf[int](opts...)
+}
+
+// CreateDynamicGraphOf making empty graph with any comparable vertices,
+// priorities of options are used by forests of all levels
+func CreateDynamicGraphOf[V comparable](opts ...Option) *DynamicGraph[V] {
+	// queries of graph don't create vertices in forests
+	opts = append(slices.Clip(opts), WithStrict())
+	return &DynamicGraph[V]{
+		levels:  []*graphLevel[V]{createGraphLevel[V](opts)},
+		edges:   make(map[V]map[V]*graphEdge),
+		options: opts,
+	}
+}
+
+// IsConnected return true if there is path between vertices
+func (g *DynamicGraph[V]) IsConnected(first, second V) bool {
+	return g.levels[0].forest.IsConnected(first, second)
+}
+
+// HasEdge return true if edge is in graph
+func (g *DynamicGraph[V]) HasEdge(first, second V) bool {
+	return g.getEdge(first, second) != nil
+}
+
+// AddEdge adds edge to graph, edges closing cycles are allowed
+//
+// returns false if edge is already in graph or it's a loop
+func (g *DynamicGraph[V]) AddEdge(first, second V) bool {
+	if first == second || g.HasEdge(first, second) {
+		return false
+	}
+
+	edge := &graphEdge{}
+	g.setEdge(first, second, edge)
+	g.dropBridges(first)
+	g.dropBridges(second)
+
+	level := g.levels[0]
+	edge.isTree = level.forest.Link(first, second)
+	level.addEdge(first, second, edge.isTree)
+
+	return true
+}
+
+// RemoveEdge removes edge from graph,
+// if it was in spanning forest, replacement edge is searched
+//
+// returns false if edge is not exist
+func (g *DynamicGraph[V]) RemoveEdge(first, second V) bool {
+	edge := g.getEdge(first, second)
+	if edge == nil {
+		return false
+	}
+
+	g.removeEdge(first, second)
+	g.dropBridges(first)
+	g.levels[edge.level].removeEdge(first, second, edge.isTree)
+	if !edge.isTree {
+		return true
+	}
+
+	for i := 0; i <= edge.level; i++ {
+		g.levels[i].forest.Cut(first, second)
+	}
+	for i := edge.level; i >= 0; i-- {
+		if g.replace(i, first, second) {
+			break
+		}
+	}
+
+	return true
+}
+
+// replace searches replacement for cut tree edge on level i
+//
+// returns true if replacement is found
+func (g *DynamicGraph[V]) replace(i int, first, second V) bool {
+	level := g.levels[i]
+	if i+1 == len(g.levels) {
+		g.levels = append(g.levels, createGraphLevel[V](g.options))
+	}
+	next := g.levels[i+1]
+
+	// work with smaller tree, so it fits to next level
+	smaller := first
+	if level.forest.ComponentSize(second) < level.forest.ComponentSize(first) {
+		smaller = second
+	}
+
+	// push all tree edges of level to the next one
+	for {
+		v, ok := level.forest.findMarked(smaller, treeEdgeMark)
+		if !ok {
+			break
+		}
+		for u := range level.tree[v] {
+			g.getEdge(v, u).level = i + 1
+			level.removeEdge(v, u, true)
+			next.forest.Link(v, u)
+			next.addEdge(v, u, true)
+		}
+	}
+
+	// check non-tree edges, edges inside the smaller tree go to the next level
+	for {
+		v, ok := level.forest.findMarked(smaller, nonTreeEdgeMark)
+		if !ok {
+			return false
+		}
+		for u := range level.nonTree[v] {
+			edge := g.getEdge(v, u)
+			level.removeEdge(v, u, false)
+			if level.forest.IsConnected(v, u) {
+				edge.level = i + 1
+				next.addEdge(v, u, false)
+				continue
+			}
+
+			// replacement found
+			edge.isTree = true
+			level.addEdge(v, u, true)
+			for j := 0; j <= i; j++ {
+				g.levels[j].forest.Link(v, u)
+			}
+			return true
+		}
+	}
+}
+
+func (g *DynamicGraph[V]) setEdge(first, second V, edge *graphEdge) {
+	g.getEdgesMap(first)[second] = edge
+	g.getEdgesMap(second)[first] = edge
+}
+
+func (g *DynamicGraph[V]) getEdge(first, second V) *graphEdge {
+	return g.edges[first][second]
+}
+
+func (g *DynamicGraph[V]) removeEdge(first, second V) {
+	g.removeHalfEdge(first, second)
+	g.removeHalfEdge(second, first)
+}
+
+func (g *DynamicGraph[V]) removeHalfEdge(from, to V) {
+	edgesMap := g.edges[from]
+	delete(edgesMap, to)
+	if len(edgesMap) == 0 {
+		delete(g.edges, from)
+	}
+}
+
+func (g *DynamicGraph[V]) getEdgesMap(v V) map[V]*graphEdge {
+	edgesMap, ok := g.edges[v]
+	// init if needed
+	if !ok {
+		edgesMap = make(map[V]*graphEdge)
+		g.edges[v] = edgesMap
+	}
+
+	return edgesMap
+}
+
+func createGraphLevel[V comparable](opts []Option) *graphLevel[V] {
+	return &graphLevel[V]{
+		forest:  CreateEulerFunc[V](nil, opts...),
+		tree:    make(adjacency[V]),
+		nonTree: make(adjacency[V]),
+	}
+}
+
+func (l *graphLevel[V]) getAdjacency(isTree bool) (adjacency[V], uint8) {
+	if isTree {
+		return l.tree, treeEdgeMark
+	}
+	return l.nonTree, nonTreeEdgeMark
+}
+
+// addEdge saves edge in level and marks its vertices in forest
+func (l *graphLevel[V]) addEdge(first, second V, isTree bool) {
+	edges, mark := l.getAdjacency(isTree)
+	edges.add(first, second)
+	edges.add(second, first)
+	l.forest.setMark(first, mark, true)
+	l.forest.setMark(second, mark, true)
+}
+
+// removeEdge removes edge from level and unmarks vertices without edges
+func (l *graphLevel[V]) removeEdge(first, second V, isTree bool) {
+	edges, mark := l.getAdjacency(isTree)
+	if edges.remove(first, second) {
+		l.forest.setMark(first, mark, false)
+	}
+	if edges.remove(second, first) {
+		l.forest.setMark(second, mark, false)
+	}
+}
+
+func (a adjacency[V]) add(from, to V) {
+	neighbours, ok := a[from]
+	if !ok {
+		neighbours = make(map[V]struct{})
+		a[from] = neighbours
+	}
+	neighbours[to] = struct{}{}
+}
+
+// remove returns true if vertex has no more edges
+func (a adjacency[V]) remove(from, to V) bool {
+	neighbours := a[from]
+	delete(neighbours, to)
+	if len(neighbours) > 0 {
+		return false
+	}
+	delete(a, from)
+	return true
+}
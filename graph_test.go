@@ -0,0 +1,127 @@
+package euler
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestDynamicGraph(t *testing.T) {
+	g := CreateDynamicGraph()
+
+	if !g.AddEdge(1, 2) || !g.AddEdge(2, 3) || !g.AddEdge(3, 1) {
+		t.Fatal("AddEdge of new edge returned false")
+	}
+	if g.AddEdge(2, 1) {
+		t.Error("AddEdge of existing edge returned true")
+	}
+	if g.AddEdge(4, 4) {
+		t.Error("AddEdge of loop returned true")
+	}
+
+	testGraphConnected(t, g, 1, 3, true)
+
+	// cycle 1-2-3-1 keeps connectivity after any cut
+	g.RemoveEdge(1, 2)
+	testGraphConnected(t, g, 1, 2, true)
+	g.RemoveEdge(2, 3)
+	testGraphConnected(t, g, 1, 2, false)
+	testGraphConnected(t, g, 1, 3, true)
+
+	if g.RemoveEdge(1, 2) {
+		t.Error("RemoveEdge of missing edge returned true")
+	}
+	if g.HasEdge(2, 3) || !g.HasEdge(1, 3) {
+		t.Error("HasEdge returned wrong value")
+	}
+}
+
+func TestDynamicGraph_Strict(t *testing.T) {
+	g := CreateDynamicGraph()
+	g.AddEdge(1, 2)
+
+	for i := 0; i < 100; i++ {
+		g.IsConnected(i, i+1)
+		g.TwoEdgeConnected(i, i+1)
+	}
+	if got := len(g.levels[0].forest.treaps); got != 2 {
+		t.Errorf("vertices of forest after queries\nExpected 2\nGot %v", got)
+	}
+	testGraphConnected(t, g, 1, 2, true)
+}
+
+func testGraphConnected(t *testing.T, g *DynamicGraph[int], first, second Vertex, expected bool) {
+	if got := g.IsConnected(first, second); got != expected {
+		t.Errorf("IsConnected(%v, %v)\nExpected %v\nGot %v", first, second, expected, got)
+	}
+}
+
+// naiveGraph checks connectivity with dfs
+type naiveGraph map[Vertex]map[Vertex]bool
+
+func (g naiveGraph) setEdge(first, second Vertex, exists bool) {
+	for _, pair := range [][2]Vertex{{first, second}, {second, first}} {
+		if g[pair[0]] == nil {
+			g[pair[0]] = make(map[Vertex]bool)
+		}
+		if exists {
+			g[pair[0]][pair[1]] = true
+		} else {
+			delete(g[pair[0]], pair[1])
+		}
+	}
+}
+
+func (g naiveGraph) isConnected(first, second Vertex) bool {
+	visited := map[Vertex]bool{first: true}
+	stack := []Vertex{first}
+	for len(stack) > 0 {
+		v := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		for u := range g[v] {
+			if !visited[u] {
+				visited[u] = true
+				stack = append(stack, u)
+			}
+		}
+	}
+	return visited[second]
+}
+
+func TestDynamicGraph_Random(t *testing.T) {
+	const numbers = 30
+	random := rand.New(rand.NewSource(1))
+	g := CreateDynamicGraph()
+	naive := make(naiveGraph)
+
+	for i := 0; i < 20000; i++ {
+		a, b := random.Intn(numbers), random.Intn(numbers)
+		switch random.Intn(3) {
+		case 0:
+			if g.AddEdge(a, b) {
+				naive.setEdge(a, b, true)
+			}
+		case 1:
+			if g.RemoveEdge(a, b) {
+				naive.setEdge(a, b, false)
+			}
+		default:
+			testGraphConnected(t, g, a, b, naive.isConnected(a, b))
+		}
+		for _, level := range g.levels {
+			testValidate(t, level.forest)
+		}
+	}
+}
+
+func TestDynamicGraph_Generic(t *testing.T) {
+	g := CreateDynamicGraphOf[string]()
+
+	g.AddEdge("a", "b")
+	g.AddEdge("b", "c")
+	g.AddEdge("c", "a")
+	g.RemoveEdge("a", "b")
+
+	if !g.IsConnected("a", "b") {
+		t.Error("IsConnected(a, b)\nExpected true\nGot false")
+	}
+}
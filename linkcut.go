@@ -0,0 +1,444 @@
+package euler
+
+// LinkCutTree structure that allows operations
+//  IsConnected
+//  Link
+//  Cut
+//  PathAggregate
+//  PathLength
+//  LCA
+// with O(log(N)) amortized complexity for any forest (Sleator, Tarjan),
+// paths are kept in splay trees, edges are nodes of paths too
+type LinkCutTree[V comparable] struct {
+	nodes map[V]*linkCutNode[V]
+	// edge nodes are saved for both directions
+	edges  map[V]map[V]*linkCutNode[V]
+	monoid *Monoid
+}
+
+// linkCutNode node of splay tree of path,
+// parent of root of splay tree is parent of path
+type linkCutNode[V comparable] struct {
+	parent, left, right *linkCutNode[V]
+	// children of node should be swapped, aggregates of node are already swapped
+	reversed bool
+	vertex   V
+	isEdge   bool
+	// data of edge
+	data interface{}
+	// aggregates of subtree in order of path and in reverse order
+	value, aggregate, reverseAggregate interface{}
+	monoid                             *Monoid
+	// number of edge nodes in subtree
+	edges int
+}
+
+// CreateLinkCutTree making empty forest with int vertices
+func CreateLinkCutTree() *LinkCutTree[int] {
+	return CreateLinkCutTreeOf[int]()
+}
+
+// CreateLinkCutTreeOf making empty forest with any comparable vertices
+func CreateLinkCutTreeOf[V comparable]() *LinkCutTree[V] {
+	return &LinkCutTree[V]{
+		nodes: make(map[V]*linkCutNode[V]),
+		edges: make(map[V]map[V]*linkCutNode[V]),
+	}
+}
+
+// IsConnected return true if vertices are in one tree,
+// unknown vertex is connected only with itself
+func (tree *LinkCutTree[V]) IsConnected(first, second V) bool {
+	if first == second {
+		return true
+	}
+	firstNode, secondNode := tree.nodes[first], tree.nodes[second]
+	return firstNode != nil && secondNode != nil && firstNode.findRoot() == secondNode.findRoot()
+}
+
+// Link creates edge in forest
+//
+// returns false if vertices are already linked
+func (tree *LinkCutTree[V]) Link(first, second V) bool {
+	return tree.LinkWithData(first, second, nil)
+}
+
+// LinkWithData creates edge with data in forest,
+// data is aggregated with values of vertices on paths, nil data is monoid identity
+//
+// returns false if vertices are already linked
+func (tree *LinkCutTree[V]) LinkWithData(first, second V, data interface{}) bool {
+	if tree.IsConnected(first, second) {
+		return false
+	}
+
+	edge := tree.createNode(first)
+	edge.isEdge = true
+	edge.data = data
+	if data != nil {
+		edge.value = data
+	}
+	edge.update()
+	tree.getEdgesMap(first)[second] = edge
+	tree.getEdgesMap(second)[first] = edge
+
+	// first - edge - second
+	firstNode := tree.getNode(first)
+	firstNode.evert()
+	firstNode.parent = edge
+	edge.parent = tree.getNode(second)
+
+	return true
+}
+
+// Cut removes given edge
+// return false if edge is not exist
+func (tree *LinkCutTree[V]) Cut(first, second V) bool {
+	edge := tree.edges[first][second]
+	if edge == nil {
+		return false
+	}
+
+	cut(tree.nodes[first], edge)
+	cut(edge, tree.nodes[second])
+	tree.removeHalfEdge(first, second)
+	tree.removeHalfEdge(second, first)
+
+	return true
+}
+
+// EdgeData returns data of edge
+//
+// returns false if edge is not exist
+func (tree *LinkCutTree[V]) EdgeData(first, second V) (interface{}, bool) {
+	edge := tree.edges[first][second]
+	if edge == nil {
+		return nil, false
+	}
+	return edge.data, true
+}
+
+// Evert makes v root of its tree
+func (tree *LinkCutTree[V]) Evert(v V) {
+	if node := tree.nodes[v]; node != nil {
+		node.evert()
+	}
+}
+
+// PathLength returns number of edges on path between vertices
+//
+// returns -1 if vertices are not connected
+func (tree *LinkCutTree[V]) PathLength(first, second V) int {
+	if first == second {
+		return 0
+	}
+	path := tree.path(first, second)
+	if path == nil {
+		return -1
+	}
+	return path.edges
+}
+
+// PathAggregate returns aggregate of values of vertices and data of edges
+// on path from first to second vertex in order of path
+//
+// returns false if vertices are not connected, monoid should be set
+func (tree *LinkCutTree[V]) PathAggregate(first, second V) (interface{}, bool) {
+	if first == second {
+		return tree.Value(first), true
+	}
+	path := tree.path(first, second)
+	if path == nil {
+		return nil, false
+	}
+	return path.aggregate, true
+}
+
+// LCA makes root the root of its tree like Evert and returns lowest common ancestor of u and v
+//
+// returns false if vertices are not connected
+func (tree *LinkCutTree[V]) LCA(root, u, v V) (V, bool) {
+	if !tree.IsConnected(root, u) || !tree.IsConnected(root, v) {
+		var zero V
+		return zero, false
+	}
+	if u == v {
+		return u, true
+	}
+
+	// path from v joins path from root to u at ancestor
+	tree.nodes[root].evert()
+	tree.nodes[u].access()
+	return tree.nodes[v].access().vertex, true
+}
+
+// SetMonoid sets aggregation of values,
+// unset values become monoid identity
+//
+// O(N) complexity
+func (tree *LinkCutTree[V]) SetMonoid(monoid Monoid) {
+	tree.monoid = &monoid
+	var roots []*linkCutNode[V]
+	for _, node := range tree.nodes {
+		roots = node.setMonoid(tree.monoid, roots)
+	}
+	for _, edges := range tree.edges {
+		for _, edge := range edges {
+			roots = edge.setMonoid(tree.monoid, roots)
+		}
+	}
+
+	// aggregates are updated from leaves of splay trees
+	for _, root := range roots {
+		root.updateSubtree()
+	}
+}
+
+// SetValue sets value of vertex
+func (tree *LinkCutTree[V]) SetValue(v V, value interface{}) {
+	node := tree.getNode(v)
+	node.access()
+	node.value = value
+	node.update()
+}
+
+// Value returns value of vertex
+func (tree *LinkCutTree[V]) Value(v V) interface{} {
+	node := tree.nodes[v]
+	if node == nil {
+		if tree.monoid != nil {
+			return tree.monoid.Identity
+		}
+		return nil
+	}
+	return node.value
+}
+
+// path returns root of splay tree with path between different vertices
+func (tree *LinkCutTree[V]) path(first, second V) *linkCutNode[V] {
+	if !tree.IsConnected(first, second) {
+		return nil
+	}
+	firstNode, secondNode := tree.nodes[first], tree.nodes[second]
+	firstNode.evert()
+	secondNode.access()
+	return secondNode
+}
+
+func (tree *LinkCutTree[V]) getNode(v V) *linkCutNode[V] {
+	result, ok := tree.nodes[v]
+	if !ok {
+		result = tree.createNode(v)
+		result.update()
+		tree.nodes[v] = result
+	}
+	return result
+}
+
+func (tree *LinkCutTree[V]) createNode(v V) *linkCutNode[V] {
+	result := &linkCutNode[V]{vertex: v, monoid: tree.monoid}
+	if tree.monoid != nil {
+		result.value = tree.monoid.Identity
+	}
+	return result
+}
+
+func (tree *LinkCutTree[V]) getEdgesMap(v V) map[V]*linkCutNode[V] {
+	edgesMap, ok := tree.edges[v]
+	// init if needed
+	if !ok {
+		edgesMap = make(map[V]*linkCutNode[V])
+		tree.edges[v] = edgesMap
+	}
+
+	return edgesMap
+}
+
+func (tree *LinkCutTree[V]) removeHalfEdge(from, to V) {
+	edgesMap := tree.edges[from]
+	delete(edgesMap, to)
+	if len(edgesMap) == 0 {
+		delete(tree.edges, from)
+	}
+}
+
+// cut removes edge between adjacent nodes
+func cut[V comparable](first, second *linkCutNode[V]) {
+	// path first - second, first is left child of second
+	first.evert()
+	second.access()
+	second.left.setParent(nil)
+	second.left = nil
+	second.update()
+}
+
+// isRoot return true if n is root of splay tree
+func (n *linkCutNode[V]) isRoot() bool {
+	return n.parent == nil || (n.parent.left != n && n.parent.right != n)
+}
+
+func (n *linkCutNode[V]) setParent(parent *linkCutNode[V]) {
+	if n != nil {
+		n.parent = parent
+	}
+}
+
+func (n *linkCutNode[V]) getEdges() int {
+	if n == nil {
+		return 0
+	}
+	return n.edges
+}
+
+// getAggregate return aggregate of n in order of path or in reverse order
+func (n *linkCutNode[V]) getAggregate(monoid *Monoid, reverse bool) interface{} {
+	if n == nil {
+		return monoid.Identity
+	}
+	if reverse {
+		return n.reverseAggregate
+	}
+	return n.aggregate
+}
+
+func (n *linkCutNode[V]) update() {
+	n.edges = n.left.getEdges() + n.right.getEdges()
+	if n.isEdge {
+		n.edges++
+	}
+	if monoid := n.monoid; monoid != nil {
+		n.aggregate = monoid.Combine(
+			monoid.Combine(n.left.getAggregate(monoid, false), n.value),
+			n.right.getAggregate(monoid, false),
+		)
+		n.reverseAggregate = monoid.Combine(
+			monoid.Combine(n.right.getAggregate(monoid, true), n.value),
+			n.left.getAggregate(monoid, true),
+		)
+	}
+}
+
+// reverse reverses order of path in subtree lazily
+func (n *linkCutNode[V]) reverse() {
+	if n != nil {
+		n.reversed = !n.reversed
+		n.aggregate, n.reverseAggregate = n.reverseAggregate, n.aggregate
+	}
+}
+
+func (n *linkCutNode[V]) push() {
+	if n.reversed {
+		n.left, n.right = n.right, n.left
+		n.left.reverse()
+		n.right.reverse()
+		n.reversed = false
+	}
+}
+
+func (n *linkCutNode[V]) rotate() {
+	parent := n.parent
+	grandparent := parent.parent
+	if !parent.isRoot() {
+		if grandparent.left == parent {
+			grandparent.left = n
+		} else {
+			grandparent.right = n
+		}
+	}
+	n.parent = grandparent
+
+	if parent.left == n {
+		parent.left = n.right
+		n.right.setParent(parent)
+		n.right = parent
+	} else {
+		parent.right = n.left
+		n.left.setParent(parent)
+		n.left = parent
+	}
+	parent.parent = n
+
+	parent.update()
+	n.update()
+}
+
+// splay makes n root of its splay tree
+func (n *linkCutNode[V]) splay() {
+	// reversions are pushed from root of splay tree
+	path := []*linkCutNode[V]{n}
+	for node := n; !node.isRoot(); node = node.parent {
+		path = append(path, node.parent)
+	}
+	for i := len(path) - 1; i >= 0; i-- {
+		path[i].push()
+	}
+
+	for !n.isRoot() {
+		parent := n.parent
+		if !parent.isRoot() {
+			if (parent.parent.left == parent) == (parent.left == n) {
+				parent.rotate()
+			} else {
+				n.rotate()
+			}
+		}
+		n.rotate()
+	}
+}
+
+// access makes path from root of tree to n preferred,
+// n becomes root of splay tree of the path and has no right child,
+// returns the last node where path from n joined preferred path of root
+func (n *linkCutNode[V]) access() *linkCutNode[V] {
+	var last *linkCutNode[V]
+	for node := n; node != nil; node = node.parent {
+		node.splay()
+		node.right = last
+		node.update()
+		last = node
+	}
+	n.splay()
+	return last
+}
+
+// evert makes n root of its tree
+func (n *linkCutNode[V]) evert() {
+	n.access()
+	n.reverse()
+}
+
+// findRoot returns root of tree of n
+func (n *linkCutNode[V]) findRoot() *linkCutNode[V] {
+	n.access()
+	root := n
+	root.push()
+	for root.left != nil {
+		root = root.left
+		root.push()
+	}
+	root.splay()
+	return root
+}
+
+// setMonoid sets monoid to n, returns roots with n if it's root of splay tree
+func (n *linkCutNode[V]) setMonoid(monoid *Monoid, roots []*linkCutNode[V]) []*linkCutNode[V] {
+	n.monoid = monoid
+	if n.value == nil {
+		n.value = monoid.Identity
+	}
+	if n.isRoot() {
+		roots = append(roots, n)
+	}
+	return roots
+}
+
+// updateSubtree updates all nodes of splay tree of n from leaves to root
+func (n *linkCutNode[V]) updateSubtree() {
+	n.push()
+	for _, child := range []*linkCutNode[V]{n.left, n.right} {
+		if child != nil {
+			child.updateSubtree()
+		}
+	}
+	n.update()
+}
@@ -0,0 +1,155 @@
+package euler
+
+import (
+	"math/rand"
+	"testing"
+)
+
+var maxMonoid = Monoid{
+	Identity: 0,
+	Combine: func(a, b interface{}) interface{} {
+		return max(a.(int), b.(int))
+	},
+}
+
+var concatMonoid = Monoid{
+	Identity: "",
+	Combine: func(a, b interface{}) interface{} {
+		return a.(string) + b.(string)
+	},
+}
+
+func TestLinkCutTree(t *testing.T) {
+	tree := CreateLinkCutTreeOf[string]()
+	for _, v := range []string{"a", "b", "c", "d"} {
+		tree.SetValue(v, v)
+	}
+	tree.SetMonoid(concatMonoid)
+	tree.Link("a", "b")
+	tree.Link("c", "b")
+	tree.Link("d", "c")
+	tree.Evert("c")
+
+	tests := []struct {
+		first, second string
+		expected      string
+		length        int
+	}{
+		{"a", "d", "abcd", 3},
+		{"d", "a", "dcba", 3},
+		{"b", "c", "bc", 1},
+		{"b", "b", "b", 0},
+	}
+	for _, test := range tests {
+		got, ok := tree.PathAggregate(test.first, test.second)
+		if !ok || got != test.expected {
+			t.Errorf("PathAggregate(%v, %v)\nExpected %v\nGot %v", test.first, test.second, test.expected, got)
+		}
+		if got := tree.PathLength(test.first, test.second); got != test.length {
+			t.Errorf("PathLength(%v, %v)\nExpected %v\nGot %v", test.first, test.second, test.length, got)
+		}
+	}
+
+	// c - b - a
+	//  \
+	//   d
+	for _, test := range []struct{ root, u, v, expected string }{
+		{"c", "a", "d", "c"},
+		{"a", "b", "d", "b"},
+		{"d", "a", "b", "b"},
+		{"b", "a", "a", "a"},
+	} {
+		if got, ok := tree.LCA(test.root, test.u, test.v); !ok || got != test.expected {
+			t.Errorf("LCA(%v, %v, %v)\nExpected %v\nGot %v", test.root, test.u, test.v, test.expected, got)
+		}
+	}
+
+	if tree.Link("a", "d") {
+		t.Error("Link of linked vertices returned true")
+	}
+	tree.Cut("b", "c")
+	if _, ok := tree.PathAggregate("a", "d"); ok || tree.PathLength("a", "d") != -1 {
+		t.Error("path between not connected vertices is found")
+	}
+}
+
+// naiveForest finds paths by dfs
+type naiveForest map[Vertex]map[Vertex]int
+
+func (f naiveForest) path(first, second Vertex) (weights []int, ok bool) {
+	parent := map[Vertex]Vertex{first: first}
+	stack := []Vertex{first}
+	for len(stack) > 0 {
+		v := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		for u := range f[v] {
+			if _, visited := parent[u]; !visited {
+				parent[u] = v
+				stack = append(stack, u)
+			}
+		}
+	}
+	if _, ok := parent[second]; !ok {
+		return nil, false
+	}
+	for v := second; v != first; v = parent[v] {
+		weights = append(weights, f[v][parent[v]])
+	}
+	return weights, true
+}
+
+func TestLinkCutTree_Random(t *testing.T) {
+	const numbers = 30
+	random := rand.New(rand.NewSource(1))
+	tree := CreateLinkCutTree()
+	tree.SetMonoid(maxMonoid)
+	euler := CreateEuler()
+	naive := make(naiveForest)
+
+	for i := 0; i < 20000; i++ {
+		a, b := random.Intn(numbers), random.Intn(numbers)
+		switch random.Intn(4) {
+		case 0:
+			weight := random.Intn(1000) + 1
+			linked := euler.Link(a, b)
+			if tree.LinkWithData(a, b, weight) != linked {
+				t.Fatalf("Link(%v, %v) differs from Euler", a, b)
+			}
+			if linked {
+				naive.setEdge(a, b, weight)
+			}
+		case 1:
+			if tree.Cut(a, b) != euler.Cut(a, b) {
+				t.Fatalf("Cut(%v, %v) differs from Euler", a, b)
+			}
+			delete(naive[a], b)
+			delete(naive[b], a)
+		case 2:
+			if tree.IsConnected(a, b) != euler.IsConnected(a, b) {
+				t.Fatalf("IsConnected(%v, %v) differs from Euler", a, b)
+			}
+		default:
+			weights, ok := naive.path(a, b)
+			expected := 0
+			for _, weight := range weights {
+				expected = max(expected, weight)
+			}
+			got, gotOk := tree.PathAggregate(a, b)
+			if gotOk != ok || ok && got != expected {
+				t.Fatalf("PathAggregate(%v, %v)\nExpected %v\nGot %v", a, b, expected, got)
+			}
+			if length := tree.PathLength(a, b); ok && length != len(weights) {
+				t.Fatalf("PathLength(%v, %v)\nExpected %v\nGot %v", a, b, len(weights), length)
+			}
+		}
+	}
+}
+
+func (f naiveForest) setEdge(first, second Vertex, weight int) {
+	for _, pair := range [][2]Vertex{{first, second}, {second, first}} {
+		if f[pair[0]] == nil {
+			f[pair[0]] = make(map[Vertex]int)
+		}
+		f[pair[0]][pair[1]] = weight
+	}
+}
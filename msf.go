@@ -0,0 +1,305 @@
+package euler
+
+import (
+	"cmp"
+	"math"
+	"slices"
+)
+
+// DynamicMSF structure that allows operations
+//  AddEdge
+//  RemoveEdge
+//  TotalWeight
+//  TreeEdges
+// for minimum spanning forest of undirected weighted graph,
+// heaviest edge of cycle is found in LinkCutTree with O(log(N)) amortized complexity,
+// non-tree edges of vertex are kept in treap sorted by weight with O(log(D)) expected complexity,
+// removed tree edge is replaced by the lightest non-tree edge from the smaller side of cut
+// with O(S*log(N)) complexity for S non-tree edges of the smaller side
+type DynamicMSF[V comparable] struct {
+	// data of edges of forest is edge, aggregate of path is its heaviest edge
+	forest *LinkCutTree[V]
+	// the same forest for sizes of trees, vertices with non-tree edges are marked
+	tours *Euler[V]
+	// edges are saved for both directions
+	edges map[V]map[V]*msfEdge[V]
+	// roots of treaps of non-tree edges of vertices sorted from the lightest
+	nonTree map[V]*Treap[*msfEdge[V]]
+	// compensated sum of weights of forest, so removed heavy edges don't lose light ones
+	total, compensation float64
+	// edges with equal weight are ordered by ids
+	lastID int
+}
+
+// WeightedEdge edge of DynamicMSF
+type WeightedEdge[V comparable] struct {
+	First, Second V
+	Weight        float64
+}
+
+type msfEdge[V comparable] struct {
+	first, second V
+	weight        float64
+	id            int
+	isTree        bool
+	// entries of non-tree edge in treaps of first and second vertices
+	entries [2]*Treap[*msfEdge[V]]
+}
+
+// CreateDynamicMSF making empty graph with int vertices
+func CreateDynamicMSF() *DynamicMSF[int] {
+	return CreateDynamicMSFOf[int]()
+}
+
+// CreateDynamicMSFOf making empty graph with any comparable vertices
+func CreateDynamicMSFOf[V comparable]() *DynamicMSF[V] {
+	forest := CreateLinkCutTreeOf[V]()
+	forest.SetMonoid(Monoid{
+		Identity: nil,
+		Combine: func(a, b interface{}) interface{} {
+			if a == nil {
+				return b
+			}
+			if b == nil || b.(*msfEdge[V]).compare(a.(*msfEdge[V])) < 0 {
+				return a
+			}
+			return b
+		},
+	})
+	return &DynamicMSF[V]{
+		forest:  forest,
+		tours:   CreateEulerFunc[V](nil, WithStrict()),
+		edges:   make(map[V]map[V]*msfEdge[V]),
+		nonTree: make(map[V]*Treap[*msfEdge[V]]),
+	}
+}
+
+// IsConnected return true if there is path between vertices
+func (f *DynamicMSF[V]) IsConnected(first, second V) bool {
+	return f.forest.IsConnected(first, second)
+}
+
+// HasEdge return true if edge is in graph
+func (f *DynamicMSF[V]) HasEdge(first, second V) bool {
+	return f.getEdge(first, second) != nil
+}
+
+// AddEdge adds edge to graph,
+// if edge closes cycle, it replaces the heaviest edge of cycle in forest if it's lighter
+//
+// returns false if edge is already in graph or it's a loop
+func (f *DynamicMSF[V]) AddEdge(first, second V, weight float64) bool {
+	if first == second || f.HasEdge(first, second) {
+		return false
+	}
+
+	f.lastID++
+	edge := &msfEdge[V]{first: first, second: second, weight: weight, id: f.lastID}
+	f.setEdge(first, second, edge)
+
+	if !f.forest.IsConnected(first, second) {
+		f.link(edge)
+		return true
+	}
+
+	heaviest, _ := f.forest.PathAggregate(first, second)
+	if old := heaviest.(*msfEdge[V]); edge.compare(old) < 0 {
+		f.cut(old)
+		f.addNonTree(old)
+		f.link(edge)
+	} else {
+		f.addNonTree(edge)
+	}
+
+	return true
+}
+
+// RemoveEdge removes edge from graph,
+// if it was in forest, the lightest edge between its trees replaces it
+//
+// returns false if edge is not exist
+func (f *DynamicMSF[V]) RemoveEdge(first, second V) bool {
+	edge := f.getEdge(first, second)
+	if edge == nil {
+		return false
+	}
+
+	f.removeEdge(first, second)
+	if !edge.isTree {
+		f.removeNonTree(edge)
+		return true
+	}
+
+	f.cut(edge)
+	if replacement := f.replacement(first, second); replacement != nil {
+		f.removeNonTree(replacement)
+		f.link(replacement)
+	}
+
+	return true
+}
+
+// replacement returns the lightest non-tree edge between trees of first and second or nil,
+// every such edge has end in the smaller tree
+func (f *DynamicMSF[V]) replacement(first, second V) *msfEdge[V] {
+	smaller := first
+	if f.tours.ComponentSize(second) < f.tours.ComponentSize(first) {
+		smaller = second
+	}
+
+	var result *msfEdge[V]
+	for _, v := range f.tours.markedOf(smaller, nonTreeEdgeMark) {
+		// the first edge of vertex to another tree is its lightest one
+		for entry := f.nonTree[v].leftmost(); entry != nil; entry = entry.next() {
+			candidate := entry.vertex
+			if result != nil && result.compare(candidate) < 0 {
+				break
+			}
+			other := candidate.first
+			if other == v {
+				other = candidate.second
+			}
+			if !f.tours.IsConnected(smaller, other) {
+				result = candidate
+				break
+			}
+		}
+	}
+	return result
+}
+
+// TotalWeight returns sum of weights of edges in forest
+func (f *DynamicMSF[V]) TotalWeight() float64 {
+	return f.total + f.compensation
+}
+
+// TreeEdges returns edges of forest from the lightest
+//
+// O(N*log(N)) complexity
+func (f *DynamicMSF[V]) TreeEdges() []WeightedEdge[V] {
+	var edges []*msfEdge[V]
+	for from, edgesMap := range f.edges {
+		for _, edge := range edgesMap {
+			// both directions are saved
+			if edge.isTree && edge.first == from {
+				edges = append(edges, edge)
+			}
+		}
+	}
+	slices.SortFunc(edges, (*msfEdge[V]).compare)
+
+	result := make([]WeightedEdge[V], 0, len(edges))
+	for _, edge := range edges {
+		result = append(result, WeightedEdge[V]{First: edge.first, Second: edge.second, Weight: edge.weight})
+	}
+	return result
+}
+
+func (f *DynamicMSF[V]) link(edge *msfEdge[V]) {
+	f.forest.LinkWithData(edge.first, edge.second, edge)
+	f.tours.Link(edge.first, edge.second)
+	edge.isTree = true
+	f.addWeight(edge.weight)
+}
+
+func (f *DynamicMSF[V]) cut(edge *msfEdge[V]) {
+	f.forest.Cut(edge.first, edge.second)
+	f.tours.Cut(edge.first, edge.second)
+	edge.isTree = false
+	f.addWeight(-edge.weight)
+}
+
+// addWeight adds weight to total by Neumaier summation,
+// low-order bits lost by rounding are kept in compensation
+func (f *DynamicMSF[V]) addWeight(weight float64) {
+	sum := f.total + weight
+	if math.Abs(f.total) >= math.Abs(weight) {
+		f.compensation += (f.total - sum) + weight
+	} else {
+		f.compensation += (weight - sum) + f.total
+	}
+	f.total = sum
+}
+
+func (f *DynamicMSF[V]) addNonTree(edge *msfEdge[V]) {
+	for i, v := range []V{edge.first, edge.second} {
+		entry := &Treap[*msfEdge[V]]{priority: nextPriority(nil), size: 1, vertex: edge}
+		edge.entries[i] = entry
+		f.nonTree[v] = insertSorted(f.nonTree[v], entry, (*msfEdge[V]).compare)
+		f.tours.setMark(v, nonTreeEdgeMark, true)
+	}
+}
+
+func (f *DynamicMSF[V]) removeNonTree(edge *msfEdge[V]) {
+	for i, v := range []V{edge.first, edge.second} {
+		entry := edge.entries[i]
+		left, right := entry.Root().Split(entry.index()).Destruct()
+		_, right = right.Split(1).Destruct()
+		edge.entries[i] = nil
+		if root := Merge(left, right); root != nil {
+			f.nonTree[v] = root
+		} else {
+			delete(f.nonTree, v)
+			f.tours.setMark(v, nonTreeEdgeMark, false)
+		}
+	}
+}
+
+// insertSorted inserts entry into treap sorted by compare, returns new root
+//
+// O(log(N)) expected complexity
+func insertSorted[V comparable](root, entry *Treap[V], compare func(a, b V) int) *Treap[V] {
+	// number of entries before entry
+	k := 0
+	for node := root; node != nil; {
+		if compare(node.vertex, entry.vertex) < 0 {
+			k += node.left.getSize() + 1
+			node = node.right
+		} else {
+			node = node.left
+		}
+	}
+	left, right := root.Split(k).Destruct()
+	return Merge(Merge(left, entry), right)
+}
+
+func (f *DynamicMSF[V]) setEdge(first, second V, edge *msfEdge[V]) {
+	f.getEdgesMap(first)[second] = edge
+	f.getEdgesMap(second)[first] = edge
+}
+
+func (f *DynamicMSF[V]) getEdge(first, second V) *msfEdge[V] {
+	return f.edges[first][second]
+}
+
+func (f *DynamicMSF[V]) removeEdge(first, second V) {
+	f.removeHalfEdge(first, second)
+	f.removeHalfEdge(second, first)
+}
+
+func (f *DynamicMSF[V]) removeHalfEdge(from, to V) {
+	edgesMap := f.edges[from]
+	delete(edgesMap, to)
+	if len(edgesMap) == 0 {
+		delete(f.edges, from)
+	}
+}
+
+func (f *DynamicMSF[V]) getEdgesMap(v V) map[V]*msfEdge[V] {
+	edgesMap, ok := f.edges[v]
+	// init if needed
+	if !ok {
+		edgesMap = make(map[V]*msfEdge[V])
+		f.edges[v] = edgesMap
+	}
+
+	return edgesMap
+}
+
+// compare orders edges by weight and then by id
+func (e *msfEdge[V]) compare(other *msfEdge[V]) int {
+	if result := cmp.Compare(e.weight, other.weight); result != 0 {
+		return result
+	}
+	return cmp.Compare(e.id, other.id)
+}
@@ -0,0 +1,131 @@
+package euler
+
+import (
+	"cmp"
+	"math/rand"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestDynamicMSF(t *testing.T) {
+	f := CreateDynamicMSF()
+
+	if !f.AddEdge(1, 2, 3) || !f.AddEdge(2, 3, 2) || !f.AddEdge(3, 4, 5) {
+		t.Fatal("AddEdge of new edge returned false")
+	}
+	if f.AddEdge(2, 1, 1) {
+		t.Error("AddEdge of existing edge returned true")
+	}
+	if f.AddEdge(4, 4, 1) {
+		t.Error("AddEdge of loop returned true")
+	}
+	testTotalWeight(t, f, 10)
+
+	// 1-4 replaces 3-4, 1-3 closes cycle with lighter edges
+	f.AddEdge(1, 4, 4)
+	f.AddEdge(1, 3, 6)
+	testTotalWeight(t, f, 9)
+	expected := []WeightedEdge[int]{{2, 3, 2}, {1, 2, 3}, {1, 4, 4}}
+	if got := f.TreeEdges(); !reflect.DeepEqual(got, expected) {
+		t.Errorf("TreeEdges()\nExpected %v\nGot %v", expected, got)
+	}
+
+	// the lightest replacement of 1-4 is 3-4
+	f.RemoveEdge(4, 1)
+	testTotalWeight(t, f, 10)
+	f.RemoveEdge(2, 3)
+	testTotalWeight(t, f, 14)
+	f.RemoveEdge(1, 3)
+	testTotalWeight(t, f, 8)
+	if f.IsConnected(1, 3) || !f.IsConnected(3, 4) {
+		t.Error("IsConnected returned wrong value")
+	}
+	if f.RemoveEdge(1, 3) {
+		t.Error("RemoveEdge of missing edge returned true")
+	}
+}
+
+func TestDynamicMSF_TotalWeight(t *testing.T) {
+	// weight of light edge is lost in rounding of sum with heavy one
+	f := CreateDynamicMSF()
+	f.AddEdge(1, 2, 1e16)
+	f.AddEdge(3, 4, 1)
+	f.RemoveEdge(1, 2)
+	testTotalWeight(t, f, 1)
+	f.AddEdge(5, 6, 0.1)
+	f.AddEdge(1, 2, -1e16)
+	f.RemoveEdge(1, 2)
+	testTotalWeight(t, f, 1.1)
+}
+
+func testTotalWeight(t *testing.T, f *DynamicMSF[int], expected float64) {
+	t.Helper()
+	if got := f.TotalWeight(); got != expected {
+		t.Errorf("TotalWeight()\nExpected %v\nGot %v", expected, got)
+	}
+}
+
+func TestDynamicMSF_Random(t *testing.T) {
+	const numbers = 30
+	random := rand.New(rand.NewSource(1))
+	f := CreateDynamicMSF()
+	weights := make(map[[2]Vertex]float64)
+
+	for i := 0; i < 5000; i++ {
+		a, b := random.Intn(numbers), random.Intn(numbers)
+		if random.Intn(3) == 0 {
+			if f.RemoveEdge(a, b) {
+				delete(weights, [2]Vertex{min(a, b), max(a, b)})
+			}
+		} else {
+			// equal weights are frequent
+			weight := float64(random.Intn(10))
+			if f.AddEdge(a, b, weight) {
+				weights[[2]Vertex{min(a, b), max(a, b)}] = weight
+			}
+		}
+
+		expected := kruskal(numbers, weights)
+		testTotalWeight(t, f, expected)
+		testValidate(t, f.tours)
+
+		// tree edges are a forest with the same weight
+		sets := createRollbackSets(numbers)
+		total := 0.0
+		for _, edge := range f.TreeEdges() {
+			if sets.find(edge.First) == sets.find(edge.Second) {
+				t.Fatalf("TreeEdges() has cycle with %v", edge)
+			}
+			sets.union(edge.First, edge.Second)
+			if weight, ok := weights[[2]Vertex{min(edge.First, edge.Second), max(edge.First, edge.Second)}]; !ok || weight != edge.Weight {
+				t.Fatalf("TreeEdges() has unknown edge %v", edge)
+			}
+			total += edge.Weight
+		}
+		if total != expected {
+			t.Fatalf("sum of TreeEdges()\nExpected %v\nGot %v", expected, total)
+		}
+	}
+}
+
+// kruskal returns weight of minimum spanning forest
+func kruskal(numbers int, weights map[[2]Vertex]float64) float64 {
+	edges := make([][2]Vertex, 0, len(weights))
+	for edge := range weights {
+		edges = append(edges, edge)
+	}
+	slices.SortFunc(edges, func(a, b [2]Vertex) int {
+		return cmp.Compare(weights[a], weights[b])
+	})
+
+	sets := createRollbackSets(numbers)
+	total := 0.0
+	for _, edge := range edges {
+		if sets.find(edge[0]) != sets.find(edge[1]) {
+			sets.union(edge[0], edge[1])
+			total += weights[edge]
+		}
+	}
+	return total
+}
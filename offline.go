@@ -0,0 +1,171 @@
+package euler
+
+// OpKind kind of graph operation
+type OpKind int
+
+const (
+	// OpIsConnected checks connectivity of vertices
+	OpIsConnected OpKind = iota
+	// OpLink creates edge
+	OpLink
+	// OpCut removes edge
+	OpCut
+)
+
+// Op operation of graph trace
+type Op[V comparable] struct {
+	Kind          OpKind
+	First, Second V
+}
+
+// ApplyOffline answers all operations of graph trace at once, cycles are allowed,
+// OpLink adds edge and OpCut removes edge like AddEdge and RemoveEdge of DynamicGraph,
+// returns results of operations like DynamicGraph returns
+//
+// each edge is alive on interval of operations, intervals are saved in segment tree over time
+// and queries are answered in its leaves by disjoint sets with rollback
+//
+// O(Q*log(Q)*log(N)) complexity for Q operations and N vertices
+func ApplyOffline[V comparable](ops []Op[V]) []bool {
+	results := make([]bool, len(ops))
+	if len(ops) == 0 {
+		return results
+	}
+
+	ids := make(map[V]int)
+	getID := func(v V) int {
+		id, ok := ids[v]
+		if !ok {
+			id = len(ids)
+			ids[v] = id
+		}
+		return id
+	}
+
+	// edges are saved by pair of ids in increasing order
+	opened := make(map[[2]int]int)
+	tree := &timeTree{nodes: make([][][2]int, 4*len(ops)), size: len(ops)}
+	queries := make([][2]int, len(ops))
+	for i, op := range ops {
+		first, second := getID(op.First), getID(op.Second)
+		if first > second {
+			first, second = second, first
+		}
+		edge := [2]int{first, second}
+
+		switch op.Kind {
+		case OpIsConnected:
+			queries[i] = edge
+		case OpLink:
+			if _, ok := opened[edge]; !ok && first != second {
+				opened[edge] = i
+				results[i] = true
+			}
+		case OpCut:
+			if start, ok := opened[edge]; ok {
+				tree.add(1, 0, tree.size, start, i, edge)
+				delete(opened, edge)
+				results[i] = true
+			}
+		}
+	}
+	for edge, start := range opened {
+		tree.add(1, 0, tree.size, start, len(ops), edge)
+	}
+
+	sets := createRollbackSets(len(ids))
+	tree.walk(1, 0, tree.size, sets, func(i int) {
+		if ops[i].Kind == OpIsConnected {
+			results[i] = sets.find(queries[i][0]) == sets.find(queries[i][1])
+		}
+	})
+
+	return results
+}
+
+// timeTree segment tree over operations, node holds edges alive on its whole interval
+type timeTree struct {
+	nodes [][][2]int
+	size  int
+}
+
+// add saves edge alive on [from, to) in nodes covering it
+func (t *timeTree) add(node, left, right, from, to int, edge [2]int) {
+	if to <= left || right <= from {
+		return
+	}
+	if from <= left && right <= to {
+		t.nodes[node] = append(t.nodes[node], edge)
+		return
+	}
+	middle := (left + right) / 2
+	t.add(2*node, left, middle, from, to, edge)
+	t.add(2*node+1, middle, right, from, to, edge)
+}
+
+// walk unites edges of node, visits leaves of node and rolls unions back
+func (t *timeTree) walk(node, left, right int, sets *rollbackSets, visit func(i int)) {
+	saved := len(sets.history)
+	for _, edge := range t.nodes[node] {
+		sets.union(edge[0], edge[1])
+	}
+
+	if right-left == 1 {
+		visit(left)
+	} else {
+		middle := (left + right) / 2
+		t.walk(2*node, left, middle, sets, visit)
+		t.walk(2*node+1, middle, right, sets, visit)
+	}
+
+	sets.rollback(saved)
+}
+
+// rollbackSets disjoint sets with union by size and without path compression,
+// so unions can be rolled back
+type rollbackSets struct {
+	parent, size []int
+	// roots of attached sets in order of unions
+	history []int
+}
+
+func createRollbackSets(n int) *rollbackSets {
+	sets := &rollbackSets{parent: make([]int, n), size: make([]int, n)}
+	for i := range sets.parent {
+		sets.parent[i] = i
+		sets.size[i] = 1
+	}
+	return sets
+}
+
+// find O(log(N)) complexity
+func (s *rollbackSets) find(v int) int {
+	for s.parent[v] != v {
+		v = s.parent[v]
+	}
+	return v
+}
+
+func (s *rollbackSets) union(first, second int) {
+	first, second = s.find(first), s.find(second)
+	if first == second {
+		return
+	}
+	if s.size[first] < s.size[second] {
+		first, second = second, first
+	}
+	s.parent[second] = first
+	s.size[first] += s.size[second]
+	s.history = append(s.history, second)
+}
+
+// rollback undoes unions until history has given length
+func (s *rollbackSets) rollback(length int) {
+	for len(s.history) > length {
+		second := s.history[len(s.history)-1]
+		s.history = s.history[:len(s.history)-1]
+		first := s.parent[second]
+		s.size[first] -= s.size[second]
+		s.parent[second] = second
+	}
+}
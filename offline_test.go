@@ -0,0 +1,81 @@
+package euler
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestApplyOffline(t *testing.T) {
+	got := ApplyOffline([]Op[string]{
+		{OpLink, "a", "b"},
+		{OpLink, "b", "c"},
+		{OpLink, "c", "a"},
+		{OpLink, "a", "a"},
+		{OpCut, "a", "b"},
+		{OpIsConnected, "a", "b"},
+		{OpCut, "b", "c"},
+		{OpIsConnected, "a", "b"},
+		{OpCut, "b", "c"},
+		{OpIsConnected, "d", "d"},
+	})
+
+	expected := []bool{true, true, true, false, true, true, true, false, false, true}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("ApplyOffline\nExpected %v\nGot %v", expected, got)
+	}
+}
+
+func TestApplyOffline_Forest(t *testing.T) {
+	const numbers = 30
+	random := rand.New(rand.NewSource(1))
+	tree := CreateEuler()
+
+	// trace without cycles
+	var ops []Op[int]
+	var expected []bool
+	for i := 0; i < 5000; i++ {
+		op := Op[int]{OpKind(random.Intn(3)), random.Intn(numbers), random.Intn(numbers)}
+		if op.Kind == OpLink && tree.IsConnected(op.First, op.Second) {
+			continue
+		}
+		ops = append(ops, op)
+		switch op.Kind {
+		case OpIsConnected:
+			expected = append(expected, tree.IsConnected(op.First, op.Second))
+		case OpLink:
+			expected = append(expected, tree.Link(op.First, op.Second))
+		case OpCut:
+			expected = append(expected, tree.Cut(op.First, op.Second))
+		}
+	}
+
+	if got := ApplyOffline(ops); !reflect.DeepEqual(got, expected) {
+		t.Errorf("ApplyOffline\nExpected %v\nGot %v", expected, got)
+	}
+}
+
+func TestApplyOffline_Graph(t *testing.T) {
+	const numbers = 30
+	random := rand.New(rand.NewSource(1))
+	g := CreateDynamicGraph()
+
+	ops := make([]Op[int], 5000)
+	expected := make([]bool, len(ops))
+	for i := range ops {
+		op := Op[int]{OpKind(random.Intn(3)), random.Intn(numbers), random.Intn(numbers)}
+		ops[i] = op
+		switch op.Kind {
+		case OpIsConnected:
+			expected[i] = g.IsConnected(op.First, op.Second)
+		case OpLink:
+			expected[i] = g.AddEdge(op.First, op.Second)
+		case OpCut:
+			expected[i] = g.RemoveEdge(op.First, op.Second)
+		}
+	}
+
+	if got := ApplyOffline(ops); !reflect.DeepEqual(got, expected) {
+		t.Errorf("ApplyOffline\nExpected %v\nGot %v", expected, got)
+	}
+}
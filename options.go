@@ -0,0 +1,70 @@
+package euler
+
+import (
+	"cmp"
+	"math/rand"
+	"sync"
+)
+
+// Option configures forest created by CreateEulerWithOptions or CreateEulerFunc,
+// CreatePersistentEulerFunc and CreateDynamicGraphOf use priorities of options
+type Option func(*options)
+
+type options struct {
+	priority func() int
+	strict   bool
+}
+
+// WithSource makes priorities of treaps by random generator of source,
+// so shapes of treaps are reproducible,
+// generator is guarded by mutex, because levels of DynamicGraph and versions of PersistentEuler share it
+func WithSource(source rand.Source) Option {
+	random := rand.New(source)
+	var mutex sync.Mutex
+	return WithPriority(func() int {
+		mutex.Lock()
+		defer mutex.Unlock()
+		return random.Int()
+	})
+}
+
+// WithPriority makes priorities of treaps by priority function,
+// priorities should be random for O(log(N)) complexity,
+// function should be safe for concurrent use if versions of PersistentEuler are derived in parallel
+func WithPriority(priority func() int) Option {
+	return func(o *options) {
+		o.priority = priority
+	}
+}
+
+// WithStrict makes queries not to create unknown vertices like SetStrict
+func WithStrict() Option {
+	return func(o *options) {
+		o.strict = true
+	}
+}
+
+func createOptions(opts []Option) options {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// CreateEulerWithOptions making empty tree with int vertices and options
+func CreateEulerWithOptions(opts ...Option) *Euler[int] {
+	return CreateEulerFunc(cmp.Compare[int], opts...)
+}
+
+// nextPriority returns priority for new entry
+func (tree *Euler[V]) nextPriority() int {
+	return nextPriority(tree.priority)
+}
+
+func nextPriority(priority func() int) int {
+	if priority != nil {
+		return priority()
+	}
+	return rand.Int()
+}
@@ -0,0 +1,87 @@
+package euler
+
+import (
+	"math/rand"
+	"sync"
+	"testing"
+)
+
+func TestCreateEulerWithOptions(t *testing.T) {
+	// trees with the same seed have the same shapes, queries of strict tree don't take priorities
+	trees := []*Euler[int]{
+		CreateEulerWithOptions(WithSource(rand.NewSource(1))),
+		CreateEulerWithOptions(WithSource(rand.NewSource(1)), WithStrict()),
+	}
+	for _, tree := range trees {
+		for i := 1; i < 100; i++ {
+			tree.Link(i, i/2)
+			if tree.strict {
+				tree.IsConnected(i+100, i+101)
+			}
+		}
+		testValidate(t, tree)
+	}
+	for v := range trees[0].treaps {
+		if trees[0].treaps[v].Root().priority != trees[1].treaps[v].Root().priority ||
+			trees[0].treaps[v].priority != trees[1].treaps[v].priority {
+			t.Fatalf("trees with the same source have different priorities of %v", v)
+		}
+	}
+
+	// decreasing priorities make treap a list
+	priority := 1000
+	tree := CreateEulerWithOptions(WithPriority(func() int { priority--; return priority }), WithStrict())
+	tree.Link(1, 2)
+	tree.Link(2, 3)
+	testValidate(t, tree)
+	if root := tree.treaps[1].Root(); root.vertex != 1 || root.left != nil {
+		t.Errorf("WithPriority: root of %v is not the first entry", tree.Strings())
+	}
+	tree.IsConnected(4, 5)
+	if tree.HasVertex(4) {
+		t.Error("WithStrict: query created vertex")
+	}
+}
+
+func TestOptions_Priority(t *testing.T) {
+	calls := 0
+	priority := WithPriority(func() int {
+		calls++
+		return rand.Int()
+	})
+
+	persistent, _ := CreatePersistentEuler(priority).Link(1, 2)
+	if calls == 0 || !persistent.IsConnected(1, 2) {
+		t.Errorf("CreatePersistentEuler: priority is called %v times", calls)
+	}
+
+	calls = 0
+	g := CreateDynamicGraph(priority)
+	g.AddEdge(1, 2)
+	g.AddEdge(2, 3)
+	g.AddEdge(3, 1)
+	g.RemoveEdge(1, 2)
+	if calls == 0 || !g.IsConnected(1, 2) {
+		t.Errorf("CreateDynamicGraph: priority is called %v times", calls)
+	}
+}
+
+func TestOptions_ConcurrentSource(t *testing.T) {
+	// versions derived in parallel share generator of source
+	base := CreatePersistentEuler(WithSource(rand.NewSource(1)))
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			version := base
+			for j := i * 100; j < i*100+99; j++ {
+				version, _ = version.Link(j, j+1)
+			}
+			if !version.IsConnected(i*100, i*100+99) {
+				t.Errorf("version of goroutine %v is not connected", i)
+			}
+		}()
+	}
+	wg.Wait()
+}
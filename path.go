@@ -0,0 +1,58 @@
+package euler
+
+// Path reroots tree at first and returns vertices of path from first to second
+//
+// returns nil if vertices are not connected,
+// O((L+D)*log(N)) complexity for L vertices of path and D edges of them
+func (tree *Euler[V]) Path(first, second V) []V {
+	if !tree.IsConnected(first, second) {
+		return nil
+	}
+	tree.Reroot(first)
+
+	result := []V{second}
+	entry, _ := tree.occurrences(second)
+	for entry.vertex != first {
+		// entry before the first entry of vertex is entry of parent
+		entry = tree.firstEntry(entry.prev())
+		result = append(result, entry.vertex)
+	}
+
+	for i, j := 0, len(result)-1; i < j; i, j = i+1, j-1 {
+		result[i], result[j] = result[j], result[i]
+	}
+	return result
+}
+
+// PathEdges reroots tree at first and returns edges of path from first to second
+//
+// returns nil if vertices are not connected
+func (tree *Euler[V]) PathEdges(first, second V) [][2]V {
+	path := tree.Path(first, second)
+	if len(path) == 0 {
+		return nil
+	}
+
+	result := make([][2]V, 0, len(path)-1)
+	for i := 1; i < len(path); i++ {
+		result = append(result, [2]V{path[i-1], path[i]})
+	}
+	return result
+}
+
+// firstEntry returns the first entry of vertex of entry,
+// tours of children between entries of vertex are skipped by edges
+func (tree *Euler[V]) firstEntry(entry *Treap[V]) *Treap[V] {
+	for entry.prev() != nil {
+		// entry is after arc from child or from parent
+		other := entry.edge.First
+		if other == entry {
+			other = entry.edge.Second
+		}
+		if other.index() > entry.index() {
+			return entry
+		}
+		entry = other.prev()
+	}
+	return entry
+}
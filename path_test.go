@@ -0,0 +1,74 @@
+package euler
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestEuler_Path(t *testing.T) {
+	// 1 - 2 - 3
+	//      \
+	//       4 - 5
+	tree := createTestTreeByLink(
+		[]struct{ a, b int }{
+			{1, 2},
+			{2, 3},
+			{2, 4},
+			{4, 5},
+		},
+		[]int{6},
+	)
+
+	tests := []struct {
+		first, second Vertex
+		expected      []Vertex
+	}{
+		{1, 5, []Vertex{1, 2, 4, 5}},
+		{5, 3, []Vertex{5, 4, 2, 3}},
+		{3, 2, []Vertex{3, 2}},
+		{4, 4, []Vertex{4}},
+		{1, 6, nil},
+		{1, 7, nil},
+	}
+	for _, test := range tests {
+		if got := tree.Path(test.first, test.second); !reflect.DeepEqual(got, test.expected) {
+			alarm(t, "Path", tree.Strings(), test.first, test.second, test.expected, got)
+		}
+	}
+
+	expected := [][2]Vertex{{5, 4}, {4, 2}, {2, 1}}
+	if got := tree.PathEdges(5, 1); !reflect.DeepEqual(got, expected) {
+		alarm(t, "PathEdges", tree.Strings(), 5, 1, expected, got)
+	}
+	if got := tree.PathEdges(3, 6); got != nil {
+		alarm(t, "PathEdges", tree.Strings(), 3, 6, nil, got)
+	}
+}
+
+func TestEuler_PathRandom(t *testing.T) {
+	const numbers = 50
+	random := rand.New(rand.NewSource(1))
+	tree := CreateEuler()
+	naive := make(naiveForest)
+
+	for i := 0; i < 1000; i++ {
+		a, b := random.Intn(numbers), random.Intn(numbers)
+		if random.Intn(3) == 0 {
+			if tree.Cut(a, b) {
+				delete(naive[a], b)
+				delete(naive[b], a)
+			}
+		} else if tree.Link(a, b) {
+			naive.setEdge(a, b, 0)
+		}
+
+		a, b = random.Intn(numbers), random.Intn(numbers)
+		got := tree.Path(a, b)
+		testValidate(t, tree)
+		expected, _ := naive.vertexPath(a, b)
+		if !reflect.DeepEqual(got, expected) {
+			t.Fatalf("Path(%v, %v)\nExpected %v\nGot %v", a, b, expected, got)
+		}
+	}
+}
@@ -0,0 +1,431 @@
+package euler
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+	"sync/atomic"
+)
+
+// PersistentTreap immutable treap with implicit key,
+// Split and Merge copy only paths from roots, so old treaps remain valid
+type PersistentTreap[V comparable] struct {
+	// id of entry is kept by copies
+	id       int
+	priority int
+	size     int
+	vertex   V
+	left     *PersistentTreap[V]
+	right    *PersistentTreap[V]
+}
+
+// Split k entries from left side of the treap
+func (t *PersistentTreap[V]) Split(k int) (*PersistentTreap[V], *PersistentTreap[V]) {
+	return t.split(k, nil)
+}
+
+// MergePersistent two treaps
+func MergePersistent[V comparable](first, second *PersistentTreap[V]) *PersistentTreap[V] {
+	return mergePersistent(first, second, nil)
+}
+
+// Stringify return euler tour tree string
+func (t *PersistentTreap[V]) Stringify() string {
+	return strings.Join(t.stringify(nil), "-")
+}
+
+func (t *PersistentTreap[V]) stringify(result []string) []string {
+	if t == nil {
+		return result
+	}
+	result = t.left.stringify(result)
+	result = append(result, formatVertex(t.vertex))
+	return t.right.stringify(result)
+}
+
+// split and merge save new nodes in copied if it's not nil
+func (t *PersistentTreap[V]) split(
+	k int,
+	copied map[*PersistentTreap[V]]bool,
+) (*PersistentTreap[V], *PersistentTreap[V]) {
+	if t == nil {
+		return nil, nil
+	}
+	if k <= 0 {
+		return nil, t
+	}
+	if k >= t.size {
+		return t, nil
+	}
+
+	result := t.copy(copied)
+	if l := t.left.getSize(); l >= k {
+		first, second := t.left.split(k, copied)
+		result.left = second
+		result.updateSize()
+		return first, result
+	}
+	first, second := t.right.split(k-t.left.getSize()-1, copied)
+	result.right = first
+	result.updateSize()
+	return result, second
+}
+
+func mergePersistent[V comparable](
+	first, second *PersistentTreap[V],
+	copied map[*PersistentTreap[V]]bool,
+) *PersistentTreap[V] {
+	if first == nil {
+		return second
+	}
+	if second == nil {
+		return first
+	}
+
+	if first.priority > second.priority {
+		result := first.copy(copied)
+		result.right = mergePersistent(first.right, second, copied)
+		result.updateSize()
+		return result
+	}
+	result := second.copy(copied)
+	result.left = mergePersistent(first, second.left, copied)
+	result.updateSize()
+	return result
+}
+
+func (t *PersistentTreap[V]) copy(copied map[*PersistentTreap[V]]bool) *PersistentTreap[V] {
+	result := *t
+	if copied != nil {
+		copied[&result] = true
+	}
+	return &result
+}
+
+func (t *PersistentTreap[V]) getSize() int {
+	if t == nil {
+		return 0
+	}
+	return t.size
+}
+
+func (t *PersistentTreap[V]) updateSize() {
+	t.size = t.left.getSize() + t.right.getSize() + 1
+}
+
+func (t *PersistentTreap[V]) leftmost() *PersistentTreap[V] {
+	for t.left != nil {
+		t = t.left
+	}
+	return t
+}
+
+func (t *PersistentTreap[V]) rightmost() *PersistentTreap[V] {
+	for t.right != nil {
+		t = t.right
+	}
+	return t
+}
+
+// PersistentEuler immutable forest, Link and Cut return new version of forest
+// and old versions remain valid, versions share unchanged parts of tours
+//
+// treaps have no parent pointers, so entries are located by map of parents
+// that is persistent too, it gives
+// O(log^2(N)) complexity of operations and O(log^2(N)) memory for each version
+type PersistentEuler[V comparable] struct {
+	// own entry of vertex
+	vertices persistentMap[V, int]
+	// entry after arc of edge, arcs are saved for both directions
+	arcs persistentMap[persistentArc[V], int]
+	// node and parent of entry
+	entries persistentMap[int, persistentEntry[V]]
+	version int
+	compare func(a, b V) int
+	// last id of entry, shared by all versions
+	ids *atomic.Int64
+	// priorities of new entries, shared by all versions, so it should be safe for concurrent use
+	priority func() int
+	// new nodes of version while it's changed
+	copied map[*PersistentTreap[V]]bool
+}
+
+type persistentArc[V comparable] struct {
+	from, to V
+}
+
+type persistentEntry[V comparable] struct {
+	node *PersistentTreap[V]
+	// id of parent entry, 0 for root
+	parent int
+}
+
+// CreatePersistentEuler making empty forest with int vertices
+func CreatePersistentEuler(opts ...Option) *PersistentEuler[int] {
+	return CreatePersistentEulerFunc(cmp.Compare[int], opts...)
+}
+
+// CreatePersistentEulerFunc making empty forest with any comparable vertices,
+// compare and priorities of options are used like in CreateEulerFunc
+func CreatePersistentEulerFunc[V comparable](compare func(a, b V) int, opts ...Option) *PersistentEuler[V] {
+	return &PersistentEuler[V]{
+		vertices: createPersistentMap[V, int](),
+		arcs:     createPersistentMap[persistentArc[V], int](),
+		entries:  createPersistentMap[int, persistentEntry[V]](),
+		compare:  compare,
+		ids:      &atomic.Int64{},
+		priority: createOptions(opts).priority,
+	}
+}
+
+// Version returns number of changes from empty forest
+func (p *PersistentEuler[V]) Version() int {
+	return p.version
+}
+
+// IsConnected return true if vertices are in one tree,
+// unknown vertex is connected only with itself
+func (p *PersistentEuler[V]) IsConnected(first, second V) bool {
+	if first == second {
+		return true
+	}
+	firstID, ok := p.vertices.get(first)
+	if !ok {
+		return false
+	}
+	secondID, ok := p.vertices.get(second)
+	return ok && p.root(firstID) == p.root(secondID)
+}
+
+// HasEdge return true if edge is in forest
+func (p *PersistentEuler[V]) HasEdge(first, second V) bool {
+	_, ok := p.arcs.get(persistentArc[V]{first, second})
+	return ok
+}
+
+// ComponentSize returns number of vertices in tree of v
+func (p *PersistentEuler[V]) ComponentSize(v V) int {
+	id, ok := p.vertices.get(v)
+	if !ok {
+		return 1
+	}
+	return (p.node(p.root(id)).size + 1) / 2
+}
+
+// Link returns version with new edge
+//
+// returns the same version and false if vertices are already linked
+func (p *PersistentEuler[V]) Link(first, second V) (*PersistentEuler[V], bool) {
+	if p.IsConnected(first, second) {
+		return p, false
+	}
+
+	result := p.change()
+	firstID, secondID := result.getEntry(first), result.getEntry(second)
+
+	// in tree
+	//  {3, 1-2-1}
+	//  link(3, 2)
+
+	// tour of second begins with its own entry
+	//  1-2-1 -> 2-1-2
+	secondRoot := result.rotate(result.node(result.root(secondID)), result.index(secondID))
+
+	// split after own entry of first and insert tour of second with duplicate of first
+	//  3 + 2-1-2 + 3
+	firstRoot := result.node(result.root(firstID))
+	left, right := firstRoot.split(result.index(firstID)+1, result.copied)
+	duplicate := result.createNode(first)
+	result.arcs = result.arcs.set(persistentArc[V]{first, second}, secondRoot.leftmost().id)
+	result.arcs = result.arcs.set(persistentArc[V]{second, first}, duplicate.id)
+
+	root := mergePersistent(mergePersistent(left, secondRoot, result.copied), duplicate, result.copied)
+	result.commit(mergePersistent(root, right, result.copied))
+	return result, true
+}
+
+// Cut returns version without given edge
+//
+// returns the same version and false if edge is not exist
+func (p *PersistentEuler[V]) Cut(first, second V) (*PersistentEuler[V], bool) {
+	enter, ok := p.arcs.get(persistentArc[V]{first, second})
+	if !ok {
+		return p, false
+	}
+	exit, _ := p.arcs.get(persistentArc[V]{second, first})
+
+	result := p.change()
+	root := result.node(result.root(enter))
+	enterIndex, exitIndex := result.index(enter), result.index(exit)
+	if enterIndex > exitIndex {
+		enter, exit = exit, enter
+		enterIndex, exitIndex = exitIndex, enterIndex
+	}
+
+	// tour of inner side is between entries of edge,
+	// entry after exit arc is removed
+	//  3-[2-1-2]-(3)
+	left, rest := root.split(enterIndex, result.copied)
+	inner, rest := rest.split(exitIndex-enterIndex, result.copied)
+	removing, right := rest.split(1, result.copied)
+
+	// relink vertex if needed
+	if id, _ := result.vertices.get(removing.vertex); id == removing.id {
+		result.vertices = result.vertices.set(removing.vertex, left.rightmost().id)
+	}
+	result.entries = result.entries.remove(removing.id)
+	result.arcs = result.arcs.remove(persistentArc[V]{first, second})
+	result.arcs = result.arcs.remove(persistentArc[V]{second, first})
+
+	result.commit(inner, mergePersistent(left, right, result.copied))
+	return result, true
+}
+
+// Strings O(N*log(N)) complexity
+func (p *PersistentEuler[V]) Strings() (result []string) {
+	roots := make(map[V]*PersistentTreap[V])
+	for _, id := range p.vertices.all() {
+		root := p.node(p.root(id))
+		roots[root.vertex] = root
+	}
+
+	keys := make([]V, 0, len(roots))
+	for key := range roots {
+		keys = append(keys, key)
+	}
+	if p.compare != nil {
+		slices.SortFunc(keys, p.compare)
+	} else {
+		slices.SortFunc(keys, func(a, b V) int {
+			return strings.Compare(formatVertex(a), formatVertex(b))
+		})
+	}
+
+	for _, key := range keys {
+		result = append(result, roots[key].Stringify())
+	}
+	return
+}
+
+// String representation
+func (p *PersistentEuler[V]) String() string {
+	return strings.Join(p.Strings(), "\n")
+}
+
+// change returns copy of version that can be changed until commit
+func (p *PersistentEuler[V]) change() *PersistentEuler[V] {
+	result := *p
+	result.version++
+	result.copied = make(map[*PersistentTreap[V]]bool)
+	return &result
+}
+
+// commit saves nodes and parents of new nodes reachable from roots of changed tours
+func (p *PersistentEuler[V]) commit(roots ...*PersistentTreap[V]) {
+	stack := make([]*PersistentTreap[V], 0, len(roots))
+	for _, root := range roots {
+		p.entries = p.entries.set(root.id, persistentEntry[V]{node: root})
+		if p.copied[root] {
+			stack = append(stack, root)
+		}
+	}
+
+	// unchanged nodes have the same children
+	for len(stack) > 0 {
+		node := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		for _, child := range []*PersistentTreap[V]{node.left, node.right} {
+			if child == nil {
+				continue
+			}
+			p.entries = p.entries.set(child.id, persistentEntry[V]{node: child, parent: node.id})
+			if p.copied[child] {
+				stack = append(stack, child)
+			}
+		}
+	}
+
+	p.copied = nil
+}
+
+// rotate returns tour that begins with entry of index i
+//
+// tour is a cycle, so its first entry is removed and
+// arc before entry i goes to new last entry
+//  1-2-3-2-1 -> 3-2-1-2-3
+func (p *PersistentEuler[V]) rotate(root *PersistentTreap[V], i int) *PersistentTreap[V] {
+	if i == 0 {
+		return root
+	}
+
+	first, rest := root.split(1, p.copied)
+	middle, tail := rest.split(i-1, p.copied)
+	before := first
+	if middle != nil {
+		before = middle.rightmost()
+	}
+	duplicate := p.createNode(tail.leftmost().vertex)
+	p.arcs = p.arcs.set(persistentArc[V]{before.vertex, duplicate.vertex}, duplicate.id)
+
+	// relink vertex if needed
+	if id, _ := p.vertices.get(first.vertex); id == first.id {
+		p.vertices = p.vertices.set(first.vertex, tail.rightmost().id)
+	}
+	p.entries = p.entries.remove(first.id)
+
+	return mergePersistent(mergePersistent(tail, middle, p.copied), duplicate, p.copied)
+}
+
+// getEntry returns id of own entry of v, creates it if needed
+func (p *PersistentEuler[V]) getEntry(v V) int {
+	id, ok := p.vertices.get(v)
+	if !ok {
+		node := p.createNode(v)
+		id = node.id
+		p.entries = p.entries.set(id, persistentEntry[V]{node: node})
+		p.vertices = p.vertices.set(v, id)
+	}
+	return id
+}
+
+func (p *PersistentEuler[V]) createNode(v V) *PersistentTreap[V] {
+	result := &PersistentTreap[V]{
+		id:       int(p.ids.Add(1)),
+		priority: nextPriority(p.priority),
+		size:     1,
+		vertex:   v,
+	}
+	p.copied[result] = true
+	return result
+}
+
+func (p *PersistentEuler[V]) node(id int) *PersistentTreap[V] {
+	entry, _ := p.entries.get(id)
+	return entry.node
+}
+
+// root returns id of root of entry treap
+func (p *PersistentEuler[V]) root(id int) int {
+	for {
+		entry, _ := p.entries.get(id)
+		if entry.parent == 0 {
+			return id
+		}
+		id = entry.parent
+	}
+}
+
+// index returns position of entry in tour
+func (p *PersistentEuler[V]) index(id int) int {
+	entry, _ := p.entries.get(id)
+	node := entry.node
+	result := node.left.getSize()
+	for entry.parent != 0 {
+		entry, _ = p.entries.get(entry.parent)
+		if entry.node.right == node {
+			result += entry.node.left.getSize() + 1
+		}
+		node = entry.node
+	}
+	return result
+}
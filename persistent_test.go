@@ -0,0 +1,106 @@
+package euler
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestPersistentTreap_Split(t *testing.T) {
+	var root *PersistentTreap[int]
+	for i := 1; i <= 5; i++ {
+		root = MergePersistent(root, &PersistentTreap[int]{priority: rand.Int(), size: 1, vertex: i})
+	}
+
+	left, right := root.Split(2)
+	if got := left.Stringify() + " " + right.Stringify(); got != "1-2 3-4-5" {
+		t.Errorf("Split(2)\nExpected 1-2 3-4-5\nGot %v", got)
+	}
+	if got := root.Stringify(); got != "1-2-3-4-5" {
+		t.Errorf("Split changed treap\nExpected 1-2-3-4-5\nGot %v", got)
+	}
+}
+
+func TestPersistentEuler(t *testing.T) {
+	empty := CreatePersistentEuler()
+	first, _ := empty.Link(1, 2)
+	second, _ := first.Link(2, 3)
+	third, _ := second.Cut(1, 2)
+
+	if _, ok := third.Link(3, 2); ok {
+		t.Error("Link of linked vertices returned true")
+	}
+	if _, ok := third.Cut(1, 2); ok {
+		t.Error("Cut of missing edge returned true")
+	}
+
+	tests := []struct {
+		version  *PersistentEuler[int]
+		expected []bool
+	}{
+		{empty, []bool{false, false}},
+		{first, []bool{true, false}},
+		{second, []bool{true, true}},
+		{third, []bool{false, true}},
+	}
+	for _, test := range tests {
+		got := []bool{test.version.IsConnected(1, 2), test.version.IsConnected(2, 3)}
+		if got[0] != test.expected[0] || got[1] != test.expected[1] {
+			t.Errorf("version %v\nExpected %v\nGot %v", test.version.Version(), test.expected, got)
+		}
+	}
+	if got := third.String(); got != "1\n2-3-2" && got != "1\n3-2-3" {
+		t.Errorf("String()\nExpected 1\\n2-3-2\nGot %v", got)
+	}
+}
+
+func TestPersistentEuler_Random(t *testing.T) {
+	const numbers = 30
+	random := rand.New(rand.NewSource(1))
+	tree := CreateEuler()
+	versions := []*PersistentEuler[int]{CreatePersistentEuler()}
+	// connectivity of all pairs in each version
+	connected := [][numbers][numbers]bool{{}}
+
+	for i := 0; i < 2000; i++ {
+		a, b := random.Intn(numbers), random.Intn(numbers)
+		last := versions[len(versions)-1]
+		var next *PersistentEuler[int]
+		var ok bool
+		if random.Intn(3) == 0 {
+			next, ok = last.Cut(a, b)
+			if ok != tree.Cut(a, b) {
+				t.Fatalf("Cut(%v, %v) returned %v", a, b, ok)
+			}
+		} else {
+			next, ok = last.Link(a, b)
+			if ok != tree.Link(a, b) {
+				t.Fatalf("Link(%v, %v) returned %v", a, b, ok)
+			}
+		}
+		if !ok {
+			continue
+		}
+		testValidate(t, tree)
+
+		var state [numbers][numbers]bool
+		for x := 0; x < numbers; x++ {
+			for y := 0; y < numbers; y++ {
+				state[x][y] = tree.IsConnected(x, y)
+			}
+			if got, expected := next.ComponentSize(x), tree.ComponentSize(x); got != expected {
+				t.Fatalf("ComponentSize(%v)\nExpected %v\nGot %v", x, expected, got)
+			}
+		}
+		versions = append(versions, next)
+		connected = append(connected, state)
+	}
+
+	// old versions are not changed by next ones
+	for i := 0; i < 1000; i++ {
+		k := random.Intn(len(versions))
+		a, b := random.Intn(numbers), random.Intn(numbers)
+		if got := versions[k].IsConnected(a, b); got != connected[k][a][b] {
+			t.Fatalf("version %v IsConnected(%v, %v)\nExpected %v\nGot %v", k, a, b, connected[k][a][b], got)
+		}
+	}
+}
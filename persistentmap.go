@@ -0,0 +1,153 @@
+package euler
+
+import (
+	"hash/maphash"
+	"iter"
+	"slices"
+)
+
+const (
+	// bits of hash used on one level of persistentMap
+	mapBits     = 4
+	mapBranches = 1 << mapBits
+)
+
+// persistentMap immutable hash trie,
+// set and remove copy only path to changed leaf, so versions share memory
+//
+// O(log(N)) complexity of operations
+type persistentMap[K comparable, T any] struct {
+	root *mapNode[K, T]
+	seed maphash.Seed
+}
+
+// mapNode is a leaf with items of one hash or a branch
+type mapNode[K comparable, T any] struct {
+	children [mapBranches]*mapNode[K, T]
+	hash     uint64
+	items    []mapItem[K, T]
+}
+
+type mapItem[K comparable, T any] struct {
+	key   K
+	value T
+}
+
+func createPersistentMap[K comparable, T any]() persistentMap[K, T] {
+	return persistentMap[K, T]{seed: maphash.MakeSeed()}
+}
+
+// get returns value of key
+//
+// returns false if key is not in map
+func (m persistentMap[K, T]) get(key K) (T, bool) {
+	hash := maphash.Comparable(m.seed, key)
+	node := m.root
+	for shift := 0; node != nil; shift += mapBits {
+		if len(node.items) > 0 {
+			if node.hash == hash {
+				for _, item := range node.items {
+					if item.key == key {
+						return item.value, true
+					}
+				}
+			}
+			break
+		}
+		node = node.children[(hash>>shift)%mapBranches]
+	}
+
+	var zero T
+	return zero, false
+}
+
+// set returns map with value of key
+func (m persistentMap[K, T]) set(key K, value T) persistentMap[K, T] {
+	m.root = m.root.set(maphash.Comparable(m.seed, key), 0, mapItem[K, T]{key, value})
+	return m
+}
+
+// remove returns map without key
+func (m persistentMap[K, T]) remove(key K) persistentMap[K, T] {
+	m.root = m.root.remove(maphash.Comparable(m.seed, key), 0, key)
+	return m
+}
+
+// all iterates over items of map in no particular order
+func (m persistentMap[K, T]) all() iter.Seq2[K, T] {
+	return func(yield func(K, T) bool) {
+		m.root.all(yield)
+	}
+}
+
+func (n *mapNode[K, T]) set(hash uint64, shift int, item mapItem[K, T]) *mapNode[K, T] {
+	if n == nil {
+		return &mapNode[K, T]{hash: hash, items: []mapItem[K, T]{item}}
+	}
+
+	if len(n.items) > 0 {
+		if n.hash == hash {
+			items := slices.Clone(n.items)
+			i := slices.IndexFunc(items, func(x mapItem[K, T]) bool { return x.key == item.key })
+			if i < 0 {
+				items = append(items, item)
+			} else {
+				items[i] = item
+			}
+			return &mapNode[K, T]{hash: hash, items: items}
+		}
+
+		// leaf becomes child of branch, hashes differ at some level
+		branch := &mapNode[K, T]{}
+		branch.children[(n.hash>>shift)%mapBranches] = n
+		n = branch
+	}
+
+	result := *n
+	i := (hash >> shift) % mapBranches
+	result.children[i] = n.children[i].set(hash, shift+mapBits, item)
+	return &result
+}
+
+func (n *mapNode[K, T]) remove(hash uint64, shift int, key K) *mapNode[K, T] {
+	if n == nil {
+		return nil
+	}
+
+	if len(n.items) > 0 {
+		i := slices.IndexFunc(n.items, func(x mapItem[K, T]) bool { return x.key == key })
+		if n.hash != hash || i < 0 {
+			return n
+		}
+		if len(n.items) == 1 {
+			return nil
+		}
+		return &mapNode[K, T]{hash: hash, items: slices.Delete(slices.Clone(n.items), i, i+1)}
+	}
+
+	i := (hash >> shift) % mapBranches
+	child := n.children[i].remove(hash, shift+mapBits, key)
+	if child == n.children[i] {
+		return n
+	}
+	result := *n
+	result.children[i] = child
+	return &result
+}
+
+func (n *mapNode[K, T]) all(yield func(K, T) bool) bool {
+	if n == nil {
+		return true
+	}
+	for _, item := range n.items {
+		if !yield(item.key, item.value) {
+			return false
+		}
+	}
+	for _, child := range n.children {
+		if !child.all(yield) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,55 @@
+package euler
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestPersistentMap(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	m := createPersistentMap[int, int]()
+	naive := make(map[int]int)
+	// old version and its copy
+	var old persistentMap[int, int]
+	oldNaive := make(map[int]int)
+
+	for i := 0; i < 10000; i++ {
+		key := random.Intn(500)
+		if random.Intn(3) == 0 {
+			m = m.remove(key)
+			delete(naive, key)
+		} else {
+			m = m.set(key, i)
+			naive[key] = i
+		}
+		if i == 5000 {
+			old = m
+			for k, v := range naive {
+				oldNaive[k] = v
+			}
+		}
+	}
+
+	for _, test := range []struct {
+		m     persistentMap[int, int]
+		naive map[int]int
+	}{{m, naive}, {old, oldNaive}} {
+		count := 0
+		for key, value := range test.m.all() {
+			count++
+			if test.naive[key] != value {
+				t.Errorf("all()\nExpected %v: %v\nGot %v: %v", key, test.naive[key], key, value)
+			}
+		}
+		if count != len(test.naive) {
+			t.Errorf("all()\nExpected %v items\nGot %v", len(test.naive), count)
+		}
+		for key := 0; key < 500; key++ {
+			value, ok := test.m.get(key)
+			expected, expectedOk := test.naive[key]
+			if value != expected || ok != expectedOk {
+				t.Errorf("get(%v)\nExpected %v %v\nGot %v %v", key, expected, expectedOk, value, ok)
+			}
+		}
+	}
+}
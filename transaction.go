@@ -0,0 +1,182 @@
+package euler
+
+// Transaction records changes of forest, so they can be undone by Rollback
+//
+// forest should be changed only through transaction until Commit or Rollback
+type Transaction[V comparable] struct {
+	tree *Euler[V]
+	// undo functions in order of changes
+	undo []func()
+	// vertices created by transaction
+	created []V
+	// vertices created by queries before transaction, Link makes them persistent
+	queried []V
+}
+
+// tourAnchor identifies rotation of tour by its first arc,
+// arcs are unique, so anchor is kept while tour changes in other places
+type tourAnchor[V comparable] struct {
+	first, second V
+	single        bool
+}
+
+// Begin starts transaction on forest
+func (tree *Euler[V]) Begin() *Transaction[V] {
+	return &Transaction[V]{tree: tree}
+}
+
+// Link creates edge in forest like Euler.Link
+//
+// returns false if vertices are already linked
+func (tx *Transaction[V]) Link(first, second V) bool {
+	return tx.LinkWithData(first, second, nil)
+}
+
+// LinkWithData creates edge with data in forest like Euler.LinkWithData
+//
+// returns false if vertices are already linked
+func (tx *Transaction[V]) LinkWithData(first, second V, data interface{}) bool {
+	tx.remember(first)
+	tx.remember(second)
+	tree := tx.tree
+	firstAnchor, secondAnchor := tree.anchorOf(first), tree.anchorOf(second)
+	if !tree.LinkWithData(first, second, data) {
+		return false
+	}
+
+	tx.undo = append(tx.undo, func() {
+		tree.Cut(first, second)
+		tree.restoreAnchor(firstAnchor)
+		tree.restoreAnchor(secondAnchor)
+	})
+	return true
+}
+
+// Cut removes given edge like Euler.Cut
+//
+// returns false if edge is not exist
+func (tx *Transaction[V]) Cut(first, second V) bool {
+	tree := tx.tree
+	edge := tree.getEdge(first, second)
+	if edge == nil {
+		return false
+	}
+
+	// tour of side of second is between arcs (first, second) and (second, first),
+	// after undo it should be between the same arcs of first and second
+	//  first-[second-afterSecond ... second]-first-afterFirst
+	toSecond, toFirst := edge.First, edge.Second
+	if toSecond.vertex != second {
+		toSecond, toFirst = toFirst, toSecond
+	}
+	afterSecond := toSecond.cyclicNext().vertex
+	afterFirst := toFirst.cyclicNext().vertex
+	anchor := tree.anchorOf(first)
+	data := edge.Data
+
+	tree.Cut(first, second)
+
+	tx.undo = append(tx.undo, func() {
+		// vertex without other edges is followed by itself
+		firstTreap, secondTreap := tree.getTreap(first), tree.getTreap(second)
+		if afterFirst != second {
+			firstTreap = tree.entryBefore(first, afterFirst)
+		}
+		if afterSecond != first {
+			secondTreap = tree.entryBefore(second, afterSecond)
+		}
+		tree.link(firstTreap, secondTreap, data)
+		tree.restoreAnchor(anchor)
+	})
+	return true
+}
+
+// Reroot rotates euler tour of v tree like Euler.Reroot
+func (tx *Transaction[V]) Reroot(v V) {
+	tx.remember(v)
+	tree := tx.tree
+	anchor := tree.anchorOf(v)
+	tree.Reroot(v)
+
+	tx.undo = append(tx.undo, func() {
+		tree.restoreAnchor(anchor)
+	})
+}
+
+// Commit keeps changes of transaction
+func (tx *Transaction[V]) Commit() {
+	tx.undo, tx.created, tx.queried = nil, nil, nil
+}
+
+// Rollback undoes changes of transaction in reverse order,
+// tours, edges and vertices of forest become the same as before Begin
+func (tx *Transaction[V]) Rollback() {
+	for i := len(tx.undo) - 1; i >= 0; i-- {
+		tx.undo[i]()
+	}
+	for _, v := range tx.queried {
+		tx.tree.treaps[v].queried = true
+	}
+	// created vertices are isolated again
+	for _, v := range tx.created {
+		delete(tx.tree.treaps, v)
+	}
+	tx.Commit()
+}
+
+// remember saves vertex if it will be created by transaction
+// or it's created by query, so Rollback restores it
+func (tx *Transaction[V]) remember(v V) {
+	treap, ok := tx.tree.treaps[v]
+	if !ok {
+		tx.created = append(tx.created, v)
+	} else if treap.queried {
+		tx.queried = append(tx.queried, v)
+	}
+}
+
+// anchorOf returns anchor of current rotation of v tour
+func (tree *Euler[V]) anchorOf(v V) tourAnchor[V] {
+	first := tree.queryTreap(v).Root().leftmost()
+	second := first.next()
+	if second == nil {
+		return tourAnchor[V]{first: first.vertex, single: true}
+	}
+	return tourAnchor[V]{first: first.vertex, second: second.vertex}
+}
+
+// restoreAnchor rotates tour so it begins with arc of anchor
+func (tree *Euler[V]) restoreAnchor(anchor tourAnchor[V]) {
+	if !anchor.single {
+		tree.reroot(tree.entryBefore(anchor.first, anchor.second))
+	}
+}
+
+// entryBefore returns entry of arc from first to second vertex of edge
+func (tree *Euler[V]) entryBefore(first, second V) *Treap[V] {
+	edge := tree.getEdge(first, second)
+	// entry of edge is after its arc
+	entry := edge.First
+	if entry.vertex != second {
+		entry = edge.Second
+	}
+	return entry.cyclicPrev()
+}
+
+// cyclicNext returns next entry of tour as cycle,
+// the first and the last entries are the same
+func (t *Treap[V]) cyclicNext() *Treap[V] {
+	if next := t.next(); next != nil {
+		return next
+	}
+	return t.Root().leftmost().next()
+}
+
+// cyclicPrev returns previous entry of tour as cycle,
+// the first and the last entries are the same
+func (t *Treap[V]) cyclicPrev() *Treap[V] {
+	if prev := t.prev(); prev != nil {
+		return prev
+	}
+	return t.Root().rightmost().prev()
+}
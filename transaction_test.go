@@ -0,0 +1,102 @@
+package euler
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestTransaction_Rollback(t *testing.T) {
+	const numbers = 30
+	random := rand.New(rand.NewSource(1))
+	tree := CreateEuler()
+	for i := 0; i < 20; i++ {
+		tree.Link(random.Intn(numbers), random.Intn(numbers))
+	}
+
+	for i := 0; i < 200; i++ {
+		was := sortedStrings(tree)
+		vertices := len(tree.treaps)
+
+		tx := tree.Begin()
+		for j := 0; j < 20; j++ {
+			// vertices out of range are created by transaction
+			a, b := random.Intn(numbers+5), random.Intn(numbers+5)
+			switch random.Intn(3) {
+			case 0:
+				tx.LinkWithData(a, b, j)
+			case 1:
+				tx.Cut(a, b)
+			default:
+				tx.Reroot(a)
+			}
+			testValidate(t, tree)
+		}
+
+		if random.Intn(2) == 0 {
+			tx.Commit()
+			continue
+		}
+		tx.Rollback()
+		testValidate(t, tree)
+
+		if got := sortedStrings(tree); !reflect.DeepEqual(got, was) {
+			t.Fatalf("Rollback\nExpected %v\nGot %v", was, got)
+		}
+		if len(tree.treaps) != vertices {
+			t.Fatalf("Rollback\nExpected %v vertices\nGot %v", vertices, len(tree.treaps))
+		}
+	}
+}
+
+func TestTransaction_RollbackEdgeData(t *testing.T) {
+	tree := CreateEuler()
+	tree.LinkWithData(1, 2, "a")
+	tree.LinkWithData(2, 3, "b")
+
+	tx := tree.Begin()
+	tx.Cut(1, 2)
+	tx.Link(1, 4)
+	if tx.Link(4, 1) {
+		t.Error("Link of linked vertices returned true")
+	}
+	tx.Rollback()
+
+	if data, ok := tree.EdgeData(1, 2); !ok || data != "a" {
+		t.Errorf("EdgeData(1, 2)\nExpected a\nGot %v", data)
+	}
+	if _, ok := tree.EdgeData(1, 4); ok {
+		t.Error("EdgeData(1, 4) returned edge after Rollback")
+	}
+	if _, ok := tree.treaps[4]; ok {
+		t.Error("vertex created by transaction is not removed")
+	}
+}
+
+func TestTransaction_Commit(t *testing.T) {
+	tree := CreateEuler()
+
+	tx := tree.Begin()
+	tx.Link(1, 2)
+	tx.Commit()
+	tx.Rollback()
+
+	testIsConnected(t, tree, 1, 2, true)
+}
+
+func TestTransaction_RollbackQueried(t *testing.T) {
+	tree := CreateEuler()
+	tree.Link(1, 2)
+	// vertices created by queries
+	tree.IsConnected(3, 4)
+
+	tx := tree.Begin()
+	tx.Reroot(3)
+	tx.Link(4, 1)
+	tx.Rollback()
+	testValidate(t, tree)
+
+	if got := tree.Compact(); got != 2 || tree.HasVertex(3) || tree.HasVertex(4) {
+		t.Errorf("Compact after Rollback\nExpected 2 removed vertices\nGot %v", got)
+	}
+}
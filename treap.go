@@ -1,64 +1,120 @@
 package euler
 
 import (
+	"fmt"
 	"strconv"
 	"strings"
 )
 
-// Vertex id
+// Vertex id used by CreateEuler
 type Vertex = int
 
 // Treap with implicit key,
 // also contain link to Edge
-type Treap struct {
+type Treap[V comparable] struct {
 	priority            int
 	size                int
-	vertex              Vertex
-	parent, left, right *Treap
-	edge                *Edge
+	vertex              V
+	parent, left, right *Treap[V]
+	edge                *Edge[V]
+	// marks of vertex (only in entry linked from Euler) and union of marks in subtree
+	marks, subtreeMarks uint8
+	// value of vertex is stored only in entry linked from Euler (own entry)
+	own bool
+	// own entry of vertex created by query, Compact forgets such vertices
+	queried bool
+	// allocated for all entries of forest with monoid or action and for own entries with values
+	values *treapValues
 }
 
-// TreapPair simple pair of treaps
-type TreapPair struct {
-	First, Second *Treap
+// treapValues value of vertex and aggregation of subtree of entry
+type treapValues struct {
+	// value of vertex is stored only in entry linked from Euler (own entry)
+	value     interface{}
+	aggregate interface{}
+	// tag of action that is applied to entry but not to its children
+	tag interface{}
+	// number of own entries in subtree
+	vertices int
 }
 
-func (p TreapPair) Destruct() (*Treap, *Treap) {
+// aggregation monoid and action of forest, nil if forest has neither of them
+type aggregation struct {
+	monoid *Monoid
+	action *Action
+}
+
+// Monoid aggregates values of vertices,
+// Combine should be associative and Identity should be neutral for it
+type Monoid struct {
+	Identity interface{}
+	Combine  func(a, b interface{}) interface{}
+}
+
+// Action updates values of vertices lazily,
+// Apply returns aggregate of count values after updating each of them by tag,
+// Compose returns tag that is equal to updating by inner and then by outer
+type Action struct {
+	Apply   func(tag, aggregate interface{}, count int) interface{}
+	Compose func(outer, inner interface{}) interface{}
+}
+
+// TreapPair[V] simple pair of treaps
+type TreapPair[V comparable] struct {
+	First, Second *Treap[V]
+}
+
+func (p TreapPair[V]) Destruct() (*Treap[V], *Treap[V]) {
 	return p.First, p.Second
 }
 
-// Edge it's only needed for Cut function
-type Edge TreapPair
+// Edge links two entries of tour after its vertices (needed for Cut function)
+// and holds data of edge
+type Edge[V comparable] struct {
+	First, Second *Treap[V]
+	Data          interface{}
+}
+
+// Split k entries from left side of the treap,
+// values of entries are not aggregated, Euler splits them with its monoid and action
+func (t *Treap[V]) Split(k int) TreapPair[V] {
+	return t.split(k, nil)
+}
 
-// Split k entries from left side of the treap
-func (t *Treap) Split(k int) TreapPair {
+func (t *Treap[V]) split(k int, a *aggregation) TreapPair[V] {
 	if t == nil {
-		return TreapPair{}
+		return TreapPair[V]{}
 	}
+	t.push(a)
 	if k == 0 {
-		return TreapPair{Second: t}
+		return TreapPair[V]{Second: t}
 	}
 	l := t.left.getSize()
 	if l >= k {
-		pair := t.left.Split(k)
+		pair := t.left.split(k, a)
 		t.left = pair.Second
 		t.left.setParent(t)
 		pair.First.setParent(nil)
-		t.updateSize()
-		pair.First.updateSize()
-		return TreapPair{First: pair.First, Second: t}
+		t.updateSize(a)
+		pair.First.updateSize(a)
+		return TreapPair[V]{First: pair.First, Second: t}
 	}
-	pair := t.right.Split(k - l - 1)
+	pair := t.right.split(k-l-1, a)
 	t.right = pair.First
 	t.right.setParent(t)
 	pair.Second.setParent(nil)
-	t.updateSize()
-	pair.Second.updateSize()
-	return TreapPair{First: t, Second: pair.Second}
+	t.updateSize(a)
+	pair.Second.updateSize(a)
+	return TreapPair[V]{First: t, Second: pair.Second}
+}
+
+// Merge makes one treap from two,
+// values of entries are not aggregated, Euler merges them with its monoid and action
+func Merge[V comparable](first, second *Treap[V]) *Treap[V] {
+	return merge(first, second, nil)
 }
 
-// Merge makes one treap from two
-func Merge(first, second *Treap) *Treap {
+func merge[V comparable](first, second *Treap[V], a *aggregation) *Treap[V] {
 	if second == nil {
 		return first
 	}
@@ -66,19 +122,21 @@ func Merge(first, second *Treap) *Treap {
 		return second
 	}
 	if first.priority > second.priority {
-		first.right = Merge(first.right, second)
+		first.push(a)
+		first.right = merge(first.right, second, a)
 		first.right.setParent(first)
-		first.updateSize()
+		first.updateSize(a)
 		return first
 	}
-	second.left = Merge(first, second.left)
+	second.push(a)
+	second.left = merge(first, second.left, a)
 	second.left.setParent(second)
-	second.updateSize()
+	second.updateSize(a)
 	return second
 }
 
 // Root returns root of t or nil
-func (t *Treap) Root() *Treap {
+func (t *Treap[V]) Root() *Treap[V] {
 	if t == nil {
 		return nil
 	}
@@ -89,8 +147,19 @@ func (t *Treap) Root() *Treap {
 	return current
 }
 
+// index return number of entries before t in its treap
+func (t *Treap[V]) index() int {
+	k := t.left.getSize()
+	for current := t; current.parent != nil; current = current.parent {
+		if current.parent.right == current {
+			k += current.parent.left.getSize() + 1
+		}
+	}
+	return k
+}
+
 // leftmost return leftmost (first) entry of t
-func (t *Treap) leftmost() *Treap {
+func (t *Treap[V]) leftmost() *Treap[V] {
 	current := t
 	for current.left != nil {
 		current = current.left
@@ -99,7 +168,7 @@ func (t *Treap) leftmost() *Treap {
 }
 
 // rightmost return rightmost (last) entry of t
-func (t *Treap) rightmost() *Treap {
+func (t *Treap[V]) rightmost() *Treap[V] {
 	current := t
 	for current.right != nil {
 		current = current.right
@@ -107,39 +176,240 @@ func (t *Treap) rightmost() *Treap {
 	return current
 }
 
+// next return next entry of tour or nil
+func (t *Treap[V]) next() *Treap[V] {
+	if t.right != nil {
+		return t.right.leftmost()
+	}
+	current := t
+	for current.parent != nil && current.parent.right == current {
+		current = current.parent
+	}
+	return current.parent
+}
+
+// prev return previous entry of tour or nil
+func (t *Treap[V]) prev() *Treap[V] {
+	if t.left != nil {
+		return t.left.rightmost()
+	}
+	current := t
+	for current.parent != nil && current.parent.left == current {
+		current = current.parent
+	}
+	return current.parent
+}
+
 // Stringify return euler tour tree string
-func (t *Treap) Stringify() string {
+func (t *Treap[V]) Stringify() string {
 	return strings.Join(t.stringify(), "-")
 }
 
-func (t *Treap) stringify() []string {
+func (t *Treap[V]) stringify() []string {
 	if t == nil {
 		return nil
 	}
-	str := strconv.Itoa(t.vertex)
+	str := formatVertex(t.vertex)
 
 	return append(append(t.left.stringify(), str), t.right.stringify()...)
 }
 
-func (t *Treap) isOnlyOne() bool {
+// appendVertices appends vertices of own entries of t in order of tour
+func (t *Treap[V]) appendVertices(result []V) []V {
+	for entry := t.leftmost(); entry != nil; entry = entry.next() {
+		if entry.own {
+			result = append(result, entry.vertex)
+		}
+	}
+	return result
+}
+
+// formatVertex formats int vertices by strconv and others by fmt
+func formatVertex[V comparable](v V) string {
+	if i, ok := any(v).(int); ok {
+		return strconv.Itoa(i)
+	}
+	return fmt.Sprint(v)
+}
+
+func (t *Treap[V]) isOnlyOne() bool {
 	return t.left == nil && t.right == nil && t.parent == nil
 }
 
-func (t *Treap) getSize() int {
+func (t *Treap[V]) getSize() int {
 	if t == nil {
 		return 0
 	}
 	return t.size
 }
 
-func (t *Treap) setParent(parent *Treap) {
+func (t *Treap[V]) setParent(parent *Treap[V]) {
 	if t != nil {
 		t.parent = parent
 	}
 }
 
-func (t *Treap) updateSize() {
+func (t *Treap[V]) getMarks() uint8 {
+	if t == nil {
+		return 0
+	}
+	return t.subtreeMarks
+}
+
+// getAggregate return aggregate of values in t, forest should have monoid
+func (t *Treap[V]) getAggregate(monoid *Monoid) interface{} {
+	if t == nil {
+		return monoid.Identity
+	}
+	return t.values.aggregate
+}
+
+// getValue return value of vertex or nil
+func (t *Treap[V]) getValue() interface{} {
+	if t.values == nil {
+		return nil
+	}
+	return t.values.value
+}
+
+// setValue sets value of own entry, values are allocated if needed
+func (t *Treap[V]) setValue(value interface{}) {
+	if t.values == nil {
+		t.values = &treapValues{}
+	}
+	t.values.value = value
+}
+
+func (t *Treap[V]) updateSize(a *aggregation) {
 	if t != nil {
 		t.size = 1 + t.left.getSize() + t.right.getSize()
+		t.subtreeMarks = t.marks | t.left.getMarks() | t.right.getMarks()
+		if a != nil {
+			t.updateValues(a)
+		}
+	}
+}
+
+// updateValues updates number of own entries and aggregate of t
+func (t *Treap[V]) updateValues(a *aggregation) {
+	values := t.values
+	values.vertices = t.left.getVertices() + t.right.getVertices()
+	if t.own {
+		values.vertices++
+	}
+	if a.monoid == nil {
+		return
+	}
+
+	result := t.left.getAggregate(a.monoid)
+	if t.own {
+		result = a.monoid.Combine(result, values.value)
+	}
+	if t.right != nil {
+		result = a.monoid.Combine(result, t.right.values.aggregate)
+	}
+	values.aggregate = result
+}
+
+func (t *Treap[V]) getVertices() int {
+	if t == nil {
+		return 0
+	}
+	return t.values.vertices
+}
+
+// setAggregation allocates values of all entries of t and recalculates aggregates,
+// tags of t should be pushed by previous aggregation
+func (t *Treap[V]) setAggregation(previous, a *aggregation) {
+	if t == nil {
+		return
+	}
+	if previous != nil {
+		t.push(previous)
+	}
+	if t.values == nil {
+		t.values = &treapValues{}
+	}
+	if t.own && t.values.value == nil && a.monoid != nil {
+		t.values.value = a.monoid.Identity
+	}
+	t.left.setAggregation(previous, a)
+	t.right.setAggregation(previous, a)
+	t.updateSize(a)
+}
+
+// apply updates values in t by tag, children are updated lazily
+func (t *Treap[V]) apply(tag interface{}, a *aggregation) {
+	if t == nil || t.values.vertices == 0 {
+		// there are no values
+		return
+	}
+	values := t.values
+	if t.own {
+		values.value = a.action.Apply(tag, values.value, 1)
+	}
+	if a.monoid != nil {
+		values.aggregate = a.action.Apply(tag, values.aggregate, values.vertices)
+	}
+	if values.tag == nil {
+		values.tag = tag
+	} else {
+		values.tag = a.action.Compose(tag, values.tag)
+	}
+}
+
+// push applies tag of t to its children
+func (t *Treap[V]) push(a *aggregation) {
+	if a != nil && t.values.tag != nil {
+		t.left.apply(t.values.tag, a)
+		t.right.apply(t.values.tag, a)
+		t.values.tag = nil
+	}
+}
+
+// pushPath pushes tags from root to t, so value of t is actual
+func (t *Treap[V]) pushPath(a *aggregation) {
+	if a == nil || a.action == nil {
+		return
+	}
+	if t.parent != nil {
+		t.parent.pushPath(a)
+	}
+	t.push(a)
+}
+
+// updatePath updates t and all its ancestors
+func (t *Treap[V]) updatePath(a *aggregation) {
+	for current := t; current != nil; current = current.parent {
+		current.updateSize(a)
+	}
+}
+
+// appendMarked appends vertices of entries of t with mark in order of tour
+func (t *Treap[V]) appendMarked(mark uint8, result []V) []V {
+	if t.getMarks()&mark == 0 {
+		return result
+	}
+	result = t.left.appendMarked(mark, result)
+	if t.marks&mark != 0 {
+		result = append(result, t.vertex)
+	}
+	return t.right.appendMarked(mark, result)
+}
+
+// findMarked return leftmost entry of t with mark or nil
+func (t *Treap[V]) findMarked(mark uint8) *Treap[V] {
+	if t.getMarks()&mark == 0 {
+		return nil
+	}
+	current := t
+	for {
+		if current.left.getMarks()&mark != 0 {
+			current = current.left
+		} else if current.marks&mark != 0 {
+			return current
+		} else {
+			current = current.right
+		}
 	}
 }
@@ -6,58 +6,58 @@ import (
 )
 
 func TestTreap_Split(t *testing.T) {
-	leafTreap := &Treap{priority: 1, size: 1}
+	leafTreap := &Treap[int]{priority: 1, size: 1}
 
 	tests := []struct {
-		treap    *Treap
+		treap    *Treap[int]
 		k        int
-		expected TreapPair
+		expected TreapPair[int]
 	}{
 		{
-			&Treap{
+			&Treap[int]{
 				priority: 3,
 				size:     3,
-				left: &Treap{
+				left: &Treap[int]{
 					priority: 2,
 					size:     2,
 					right:    leafTreap,
 				},
 			},
 			1,
-			TreapPair{
-				First:  &Treap{priority: 2, size: 1},
-				Second: &Treap{priority: 3, size: 2, left: leafTreap},
+			TreapPair[int]{
+				First:  &Treap[int]{priority: 2, size: 1},
+				Second: &Treap[int]{priority: 3, size: 2, left: leafTreap},
 			},
 		},
 		{
-			&Treap{
+			&Treap[int]{
 				priority: 3,
 				size:     3,
-				left: &Treap{
+				left: &Treap[int]{
 					priority: 2,
 					size:     2,
 					right:    leafTreap,
 				},
 			},
 			2,
-			TreapPair{
-				First:  &Treap{priority: 2, size: 2, right: leafTreap},
-				Second: &Treap{priority: 3, size: 1},
+			TreapPair[int]{
+				First:  &Treap[int]{priority: 2, size: 2, right: leafTreap},
+				Second: &Treap[int]{priority: 3, size: 1},
 			},
 		},
 		{
-			&Treap{priority: 2, size: 2, left: leafTreap},
+			&Treap[int]{priority: 2, size: 2, left: leafTreap},
 			1,
-			TreapPair{
+			TreapPair[int]{
 				First:  leafTreap,
-				Second: &Treap{priority: 2, size: 1},
+				Second: &Treap[int]{priority: 2, size: 1},
 			},
 		},
 		{
-			&Treap{priority: 2, size: 2, right: leafTreap},
+			&Treap[int]{priority: 2, size: 2, right: leafTreap},
 			1,
-			TreapPair{
-				First:  &Treap{priority: 2, size: 1},
+			TreapPair[int]{
+				First:  &Treap[int]{priority: 2, size: 1},
 				Second: leafTreap,
 			},
 		},
@@ -73,43 +73,43 @@ func TestTreap_Split(t *testing.T) {
 }
 
 func TestTreap_Merge(t *testing.T) {
-	first := &Treap{priority: 1, size: 1}
-	firstParent := &Treap{priority: 2, size: 2, left: first}
+	first := &Treap[int]{priority: 1, size: 1}
+	firstParent := &Treap[int]{priority: 2, size: 2, left: first}
 	first.parent = firstParent
 
-	second := &Treap{priority: 1, size: 1}
-	secondParent := &Treap{priority: 2, size: 2, right: second}
+	second := &Treap[int]{priority: 1, size: 1}
+	secondParent := &Treap[int]{priority: 2, size: 2, right: second}
 	second.parent = secondParent
 
-	thirdLeft := &Treap{priority: 3, size: 2, right: &Treap{priority: 2, size: 1}}
+	thirdLeft := &Treap[int]{priority: 3, size: 2, right: &Treap[int]{priority: 2, size: 1}}
 	thirdLeft.right.setParent(thirdLeft)
-	thirdRight := &Treap{priority: 1, size: 1}
-	thirdResult := &Treap{
+	thirdRight := &Treap[int]{priority: 1, size: 1}
+	thirdResult := &Treap[int]{
 		priority: 3,
 		size:     3,
-		right:    &Treap{priority: 2, size: 2, right: &Treap{priority: 1, size: 1}},
+		right:    &Treap[int]{priority: 2, size: 2, right: &Treap[int]{priority: 1, size: 1}},
 	}
 	thirdResult.right.setParent(thirdResult)
 	thirdResult.right.right.setParent(thirdResult.right)
 
 	tests := []struct {
-		first, second *Treap
-		expected      *Treap
+		first, second *Treap[int]
+		expected      *Treap[int]
 	}{
 		{
-			&Treap{priority: 1, size: 1},
-			&Treap{priority: 2, size: 1},
+			&Treap[int]{priority: 1, size: 1},
+			&Treap[int]{priority: 2, size: 1},
 			firstParent,
 		},
 		{
-			&Treap{priority: 2, size: 1},
-			&Treap{priority: 1, size: 1},
+			&Treap[int]{priority: 2, size: 1},
+			&Treap[int]{priority: 1, size: 1},
 			secondParent,
 		},
 		{
 			nil,
-			&Treap{priority: 2, size: 1},
-			&Treap{priority: 2, size: 1},
+			&Treap[int]{priority: 2, size: 1},
+			&Treap[int]{priority: 2, size: 1},
 		},
 		{
 			thirdLeft,
@@ -128,12 +128,12 @@ func TestTreap_Merge(t *testing.T) {
 }
 
 func TestTreap_FindRoot(t *testing.T) {
-	child := &Treap{priority: 1, size: 1}
-	parent := Merge(child, &Treap{priority: 2, size: 1})
+	child := &Treap[int]{priority: 1, size: 1}
+	parent := Merge(child, &Treap[int]{priority: 2, size: 1})
 
 	tests := []struct {
-		in       *Treap
-		expected *Treap
+		in       *Treap[int]
+		expected *Treap[int]
 	}{
 		{
 			child,
@@ -164,7 +164,7 @@ func TestTreap_Stringify(t *testing.T) {
 	v2 := 2
 
 	tests := []struct {
-		in       *Treap
+		in       *Treap[int]
 		expected string
 	}{
 		{
@@ -172,21 +172,21 @@ func TestTreap_Stringify(t *testing.T) {
 			"",
 		},
 		{
-			&Treap{vertex: v0},
+			&Treap[int]{vertex: v0},
 			"0",
 		},
 		{
-			&Treap{
+			&Treap[int]{
 				vertex: v2,
-				right:  &Treap{vertex: v1},
+				right:  &Treap[int]{vertex: v1},
 			},
 			"2-1",
 		},
 		{
-			&Treap{
+			&Treap[int]{
 				vertex: v2,
-				left:   &Treap{vertex: v1},
-				right:  &Treap{vertex: v0},
+				left:   &Treap[int]{vertex: v1},
+				right:  &Treap[int]{vertex: v0},
 			},
 			"1-2-0",
 		},
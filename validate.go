@@ -0,0 +1,185 @@
+package euler
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// ErrCorrupted structure of forest is broken
+var ErrCorrupted = errors.New("euler: corrupted structure")
+
+// Validate checks treaps, tours, own entries and edges of forest,
+// aggregates are recalculated and compared by reflect.DeepEqual if monoid is set,
+// returns ErrCorrupted with description of first found problem
+//
+// O(N) complexity, it's for debugging and tests
+func (tree *Euler[V]) Validate() error {
+	// own entries of all tours
+	owns := 0
+	for _, root := range tree.roots() {
+		if root.parent != nil {
+			return corrupted("root of %v has parent", formatVertex(root.vertex))
+		}
+		if err := root.validate(tree.aggregation); err != nil {
+			return err
+		}
+		count, err := tree.validateTour(root)
+		if err != nil {
+			return err
+		}
+		owns += count
+	}
+	if owns != len(tree.treaps) {
+		return corrupted("%v own entries for %v vertices", owns, len(tree.treaps))
+	}
+
+	for v, treap := range tree.treaps {
+		if treap.vertex != v || !treap.own {
+			return corrupted("entry of %v is not own entry of it", formatVertex(v))
+		}
+	}
+
+	return tree.validateEdges()
+}
+
+// validate checks heap order, parents, sizes, marks and aggregates of subtree,
+// values of entries are updated only for aggregation, so they are checked if it's set
+func (t *Treap[V]) validate(a *aggregation) error {
+	for _, child := range []*Treap[V]{t.left, t.right} {
+		if child == nil {
+			continue
+		}
+		if child.parent != t {
+			return corrupted("wrong parent of entry %v", formatVertex(child.vertex))
+		}
+		if child.priority > t.priority {
+			return corrupted("heap order is broken at entry %v", formatVertex(child.vertex))
+		}
+		if err := child.validate(a); err != nil {
+			return err
+		}
+	}
+
+	switch {
+	case t.size != t.left.getSize()+t.right.getSize()+1:
+		return corrupted("wrong size of entry %v", formatVertex(t.vertex))
+	case t.subtreeMarks != t.marks|t.left.getMarks()|t.right.getMarks():
+		return corrupted("wrong marks of entry %v", formatVertex(t.vertex))
+	case a == nil:
+		return nil
+	case t.values == nil:
+		return corrupted("entry %v has no values", formatVertex(t.vertex))
+	}
+
+	vertices := t.left.getVertices() + t.right.getVertices()
+	if t.own {
+		vertices++
+	}
+	switch {
+	case t.values.vertices != vertices:
+		return corrupted("wrong number of vertices of entry %v", formatVertex(t.vertex))
+	case a.monoid != nil && !reflect.DeepEqual(t.values.aggregate, t.expectedAggregate(a)):
+		return corrupted("wrong aggregate of entry %v", formatVertex(t.vertex))
+	}
+	return nil
+}
+
+// expectedAggregate returns aggregate of t by its value and aggregates of children,
+// pending tag of t is not applied to children yet
+func (t *Treap[V]) expectedAggregate(a *aggregation) interface{} {
+	tag := t.values.tag
+	child := func(c *Treap[V]) interface{} {
+		if tag != nil && c.values.vertices > 0 {
+			return a.action.Apply(tag, c.values.aggregate, c.values.vertices)
+		}
+		return c.values.aggregate
+	}
+
+	result := a.monoid.Identity
+	if t.left != nil {
+		result = child(t.left)
+	}
+	if t.own {
+		result = a.monoid.Combine(result, t.values.value)
+	}
+	if t.right != nil {
+		result = a.monoid.Combine(result, child(t.right))
+	}
+	return result
+}
+
+// validateTour checks that adjacent entries are linked by edges
+// and every vertex has one own entry linked from Euler,
+// returns number of own entries
+func (tree *Euler[V]) validateTour(root *Treap[V]) (int, error) {
+	if root.size%2 == 0 {
+		return 0, corrupted("tour of %v has even length", formatVertex(root.vertex))
+	}
+
+	// each edge is passed in both directions
+	arcs := make(map[[2]V]bool)
+	owns := 0
+	first := root.leftmost()
+	var last *Treap[V]
+	for entry := first; entry != nil; entry = entry.next() {
+		if entry.own {
+			if tree.treaps[entry.vertex] != entry {
+				return 0, corrupted("own entry of %v is not linked from Euler", formatVertex(entry.vertex))
+			}
+			owns++
+		}
+		if last != nil {
+			arc := [2]V{last.vertex, entry.vertex}
+			if tree.getEdge(last.vertex, entry.vertex) == nil || arcs[arc] {
+				return 0, corrupted("wrong step %v-%v of tour", formatVertex(last.vertex), formatVertex(entry.vertex))
+			}
+			arcs[arc] = true
+		}
+		last = entry
+	}
+
+	if first.vertex != last.vertex {
+		return 0, corrupted("tour of %v is not cycle", formatVertex(first.vertex))
+	}
+	if owns != (root.size+1)/2 {
+		return 0, corrupted("tour of %v has %v own entries", formatVertex(first.vertex), owns)
+	}
+	return owns, nil
+}
+
+// validateEdges checks that edges are symmetric and their entries are after arcs of edges
+func (tree *Euler[V]) validateEdges() error {
+	for from, edges := range tree.edges {
+		for to, edge := range edges {
+			if tree.getEdge(to, from) != edge {
+				return corrupted("edge %v-%v is not symmetric", formatVertex(from), formatVertex(to))
+			}
+			if edge.First == nil || edge.Second == nil ||
+				edge.First.edge != edge || edge.Second.edge != edge ||
+				edge.First.Root() != edge.Second.Root() {
+				return corrupted("wrong entries of edge %v-%v", formatVertex(from), formatVertex(to))
+			}
+
+			for _, entry := range []*Treap[V]{edge.First, edge.Second} {
+				other := from
+				if entry.vertex == from {
+					other = to
+				} else if entry.vertex != to {
+					return corrupted("wrong entries of edge %v-%v", formatVertex(from), formatVertex(to))
+				}
+				if entry.cyclicPrev().vertex != other {
+					return corrupted("entry of edge %v-%v is not after its arc", formatVertex(from), formatVertex(to))
+				}
+			}
+			if edge.First.vertex == edge.Second.vertex {
+				return corrupted("wrong entries of edge %v-%v", formatVertex(from), formatVertex(to))
+			}
+		}
+	}
+	return nil
+}
+
+func corrupted(format string, args ...interface{}) error {
+	return fmt.Errorf("%w: "+format, append([]interface{}{ErrCorrupted}, args...)...)
+}
@@ -0,0 +1,60 @@
+package euler
+
+import (
+	"errors"
+	"testing"
+)
+
+func testValidate(t *testing.T, tree *Euler[int]) {
+	t.Helper()
+	if err := tree.Validate(); err != nil {
+		t.Fatalf("%v.Validate()\nExpected nil\nGot %v", tree.Strings(), err)
+	}
+}
+
+func TestEuler_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		corrupt func(tree *Euler[int])
+	}{
+		{"size", func(tree *Euler[int]) { tree.treaps[1].Root().size++ }},
+		{"aggregate", func(tree *Euler[int]) {
+			tree.SetMonoid(sumMonoid)
+			tree.SetAction(addAction)
+			tree.SetValue(3, 3)
+			tree.ComponentApply(1, 1)
+			tree.treaps[1].Root().values.aggregate = 0
+		}},
+		{"parent", func(tree *Euler[int]) {
+			root := tree.treaps[1].Root()
+			child := root.left
+			if child == nil {
+				child = root.right
+			}
+			child.parent = nil
+		}},
+		{"own entry", func(tree *Euler[int]) { tree.treaps[2] = tree.treaps[3] }},
+		{"edge", func(tree *Euler[int]) { tree.removeEdge(1, 2) }},
+		{"edge entries", func(tree *Euler[int]) {
+			edge := tree.getEdge(2, 3)
+			edge.First, edge.Second = edge.Second.edge.First, edge.Second.edge.First
+		}},
+	}
+
+	for _, test := range tests {
+		tree := createTestTreeByLink(
+			[]struct{ a, b int }{
+				{1, 2},
+				{2, 3},
+				{4, 2},
+			},
+			[]int{5},
+		)
+		testValidate(t, tree)
+
+		test.corrupt(tree)
+		if err := tree.Validate(); !errors.Is(err, ErrCorrupted) {
+			t.Errorf("Validate() after broken %v\nExpected %v\nGot %v", test.name, ErrCorrupted, err)
+		}
+	}
+}